@@ -1,9 +1,11 @@
 package openproject
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -66,3 +68,138 @@ func TestActivitiesService_GetFromWPHref(t *testing.T) {
 		t.Errorf("Unexpected activities count %d", activities.Count)
 	}
 }
+
+func TestActivitiesService_GetByWorkPackage_Details(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-activities.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testMux.HandleFunc("/api/v3/work_packages/36353/activities", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(raw))
+	})
+
+	activities, _, err := testClient.Activities.GetByWorkPackage("36353")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	changes := make(map[string]ActivityDetail)
+	for _, activity := range activities.Elements() {
+		for _, detail := range activity.Details {
+			changes[detail.Attribute] = detail
+		}
+	}
+
+	tests := []ActivityDetail{
+		{Attribute: "Type", Action: DetailSet, NewValue: "Bug"},
+		{Attribute: "Progress (%)", Action: DetailChanged, OldValue: "0", NewValue: "0"},
+		{Attribute: "Assignee", Action: DetailDeleted, OldValue: "Oliver Günther"},
+		{Attribute: "Description", Action: DetailChanged, Href: "/journals/357805/diff/description"},
+		{Attribute: "File", Action: DetailAdded, NewValue: "image.png",
+			Href: "https://community.openproject.org/api/v3/attachments/20683/content"},
+		{Attribute: "Manual scheduling"},
+	}
+	for _, want := range tests {
+		got := changes[want.Attribute]
+		if got.Action != want.Action || got.OldValue != want.OldValue || got.NewValue != want.NewValue || got.Href != want.Href {
+			t.Errorf("%s: expected %s %q -> %q %s. Got %s %q -> %q %s", want.Attribute,
+				want.Action, want.OldValue, want.NewValue, want.Href, got.Action, got.OldValue, got.NewValue, got.Href)
+		}
+	}
+}
+
+func TestActivityDetail_Raw(t *testing.T) {
+	var detail ActivityDetail
+	if err := json.Unmarshal([]byte(`{"format":"custom","raw":"Status changed from New to In progress"}`), &detail); err != nil {
+		t.Fatal(err)
+	}
+	if detail.Attribute != "Status" || detail.OldValue != "New" || detail.NewValue != "In progress" {
+		t.Errorf("Unexpected detail %+v", detail)
+	}
+}
+
+func TestWorkPackageService_AddComment(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/work_packages/36353/activities", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if notify := r.URL.Query().Get("notify"); notify != "false" {
+			t.Errorf("Expected notify=false. Got %q", notify)
+		}
+		var body map[string]map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		if body["comment"]["raw"] != "Fixed in **13.1**" {
+			t.Errorf("Unexpected comment %v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"_type":"Activity::Comment","id":357990,"comment":{"format":"markdown","raw":"Fixed in **13.1**"},
+			"_links":{"update":{"href":"/api/v3/activities/357990","method":"patch"}}}`)
+	})
+	testMux.HandleFunc("/api/v3/activities/357990", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		fmt.Fprint(w, `{"_type":"Activity::Comment","id":357990,"comment":{"format":"markdown","raw":"Fixed in **13.2**"}}`)
+	})
+
+	activity, _, err := testClient.WorkPackage.AddComment("36353", "Fixed in **13.1**", false)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	activity, _, err = testClient.Activities.Update(activity, "Fixed in **13.2**")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if activity.Comment.Raw != "Fixed in **13.2**" {
+		t.Errorf("Expected updated comment. Got %q", activity.Comment.Raw)
+	}
+
+	if _, _, err := testClient.Activities.Update(&Activity{Id: 1}, "not mine"); err == nil {
+		t.Error("Expected error updating an activity without update link")
+	}
+}
+
+func TestActivityDetail_RawAmbiguous(t *testing.T) {
+	var detail ActivityDetail
+	if err := json.Unmarshal([]byte(`{"format":"custom","raw":"Subject changed from Move to cloud to Stay"}`), &detail); err != nil {
+		t.Fatal(err)
+	}
+	if detail.Attribute != "Subject" || detail.Action != DetailChanged || detail.OldValue != "" || detail.NewValue != "" {
+		t.Errorf("Expected a change without values. Got %+v", detail)
+	}
+
+	// The HTML description tells the values apart
+	raw := `{"format":"custom","raw":"Subject changed from Move to cloud to Stay",` +
+		`"html":"<strong>Subject</strong> changed from <i title=\"Move to cloud\">Move to cloud</i> <strong>to</strong> <i title=\"Stay\">Stay</i>"}`
+	if err := json.Unmarshal([]byte(raw), &detail); err != nil {
+		t.Fatal(err)
+	}
+	if detail.OldValue != "Move to cloud" || detail.NewValue != "Stay" {
+		t.Errorf("Expected the values from the HTML description. Got %+v", detail)
+	}
+}
+
+func TestActivityDetail_NotEnglish(t *testing.T) {
+	raw := `{"format":"custom","raw":"Status geändert von Neu zu Geschlossen",` +
+		`"html":"<strong>Status</strong> geändert von <i title=\"Neu\">Neu</i> <strong>zu</strong> <i title=\"Geschlossen\">Geschlossen</i>"}`
+	var detail ActivityDetail
+	if err := json.Unmarshal([]byte(raw), &detail); err != nil {
+		t.Fatal(err)
+	}
+	if detail.Action != "" || detail.OldValue != "" || detail.NewValue != "" {
+		t.Errorf("Expected a change in German not to be decoded. Got %+v", detail)
+	}
+	if detail.Raw != "Status geändert von Neu zu Geschlossen" || !strings.Contains(detail.HTML, "geändert von") {
+		t.Errorf("Expected the descriptions to be kept. Got %+v", detail)
+	}
+
+	detail = ActivityDetail{}
+	if err := json.Unmarshal([]byte(`{"format":"custom","raw":"Status geändert von Neu zu Geschlossen"}`), &detail); err != nil {
+		t.Fatal(err)
+	}
+	if detail.Action != "" || detail.Raw != "Status geändert von Neu zu Geschlossen" {
+		t.Errorf("Expected a raw change in German not to be decoded. Got %+v", detail)
+	}
+}
@@ -2,9 +2,14 @@ package openproject
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"html"
+	"net/url"
+	"regexp"
 	"strings"
-	"time"
+
+	"github.com/pkg/errors"
 )
 
 // ActivitiesService handles activities for the OpenProject instance / API.
@@ -13,12 +18,12 @@ type ActivitiesService struct {
 }
 
 type Activity struct {
-	Type      string                 `json:"_type"`
-	Id        int                    `json:"id"`
-	Comment   OPGenericDescription   `json:"comment"`
-	Details   []OPGenericDescription `json:"details"`
-	Version   int                    `json:"version"`
-	CreatedAt time.Time              `json:"createdAt"`
+	Type      string               `json:"_type"`
+	Id        int                  `json:"id"`
+	Comment   OPGenericDescription `json:"comment"`
+	Details   []ActivityDetail     `json:"details"`
+	Version   int                  `json:"version"`
+	CreatedAt *Time                `json:"createdAt"`
 	Links     struct {
 		Self        OPGenericLink `json:"self"`
 		WorkPackage OPGenericLink `json:"workPackage"`
@@ -27,6 +32,120 @@ type Activity struct {
 	} `json:"_links"`
 }
 
+// ActivityDetailAction is the kind of change described by an ActivityDetail
+type ActivityDetailAction string
+
+// Constants to represent the changes of an activity
+const (
+	// DetailSet is a value set on an empty attribute
+	DetailSet ActivityDetailAction = "set"
+	// DetailChanged is a value replaced by another one
+	DetailChanged ActivityDetailAction = "changed"
+	// DetailDeleted is a value removed from an attribute
+	DetailDeleted ActivityDetailAction = "deleted"
+	// DetailAdded is an element added, like a file
+	DetailAdded ActivityDetailAction = "added"
+	// DetailRemoved is an element removed, like a file
+	DetailRemoved ActivityDetailAction = "removed"
+)
+
+// ActivityDetail is a change of a work-package attribute within an activity, e.g. "Status changed from New to Closed".
+// Attribute, Action, OldValue, NewValue and Href are decoded from the HTML (or raw) description of the change.
+// OpenProject writes these descriptions in the language of the user, and only English ones are decoded:
+// in other languages Action is left empty, and the change is only available as Raw and HTML text.
+type ActivityDetail struct {
+	Format string `json:"format,omitempty" structs:"format,omitempty"`
+	Raw    string `json:"raw,omitempty" structs:"raw,omitempty"`
+	HTML   string `json:"html,omitempty" structs:"html,omitempty"`
+
+	// Attribute is the name of the changed attribute as displayed, e.g. Status or Progress (%)
+	Attribute string `json:"-" structs:"-"`
+	// Action is empty when the change could not be recognized, e.g. "Manual scheduling activated"
+	Action   ActivityDetailAction `json:"-" structs:"-"`
+	OldValue string               `json:"-" structs:"-"`
+	NewValue string               `json:"-" structs:"-"`
+	// Href links to the diff of long text attributes like Description, or to the added file
+	Href string `json:"-" structs:"-"`
+}
+
+// Regular expressions decoding the descriptions of activity details
+var (
+	detailAttributeHTML = regexp.MustCompile(`^<strong>(.*?)</strong>`)
+	detailValueHTML     = regexp.MustCompile(`<i title="([^"]*)"`)
+	detailLinkHTML      = regexp.MustCompile(`<a [^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	detailChangedRaw    = regexp.MustCompile(`^(.+?) changed from (.*)$`)
+	detailSetRaw        = regexp.MustCompile(`^(.+?) set to (.*)$`)
+	detailDeletedRaw    = regexp.MustCompile(`^(.+?) deleted \((.*)\)$`)
+)
+
+// UnmarshalJSON decodes the description of the change and the changed attribute and values
+func (d *ActivityDetail) UnmarshalJSON(b []byte) error {
+	type description ActivityDetail
+	var raw description
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*d = ActivityDetail(raw)
+	if d.HTML != "" {
+		d.parseHTML()
+	} else {
+		d.parseRaw()
+	}
+	return nil
+}
+
+// parseHTML decodes the change from its HTML description,
+// e.g. <strong>Status</strong> changed from <i title="New">New</i> <strong>to</strong> <i title="Closed">Closed</i>
+func (d *ActivityDetail) parseHTML() {
+	attribute := detailAttributeHTML.FindStringSubmatch(d.HTML)
+	if attribute == nil {
+		return
+	}
+	d.Attribute = html.UnescapeString(attribute[1])
+	rest := d.HTML[len(attribute[0]):]
+
+	var values []string
+	for _, value := range detailValueHTML.FindAllStringSubmatch(rest, -1) {
+		values = append(values, html.UnescapeString(value[1]))
+	}
+	link := detailLinkHTML.FindStringSubmatch(rest)
+	if link != nil {
+		d.Href = html.UnescapeString(link[1])
+	}
+
+	switch {
+	case strings.HasPrefix(rest, " changed from ") && len(values) == 2:
+		d.Action, d.OldValue, d.NewValue = DetailChanged, values[0], values[1]
+	case strings.HasPrefix(rest, " set to ") && len(values) == 1:
+		d.Action, d.NewValue = DetailSet, values[0]
+	case strings.HasPrefix(rest, " deleted") && len(values) == 1:
+		d.Action, d.OldValue = DetailDeleted, values[0]
+	case strings.HasPrefix(rest, " set") || strings.HasPrefix(rest, " changed"):
+		// Long texts only link to their diff
+		d.Action = DetailChanged
+	case strings.HasSuffix(rest, " added") && link != nil:
+		d.Action, d.NewValue = DetailAdded, html.UnescapeString(link[2])
+	case strings.HasSuffix(rest, " deleted") && link != nil, strings.HasSuffix(rest, " removed") && link != nil:
+		d.Action, d.OldValue = DetailRemoved, html.UnescapeString(link[2])
+	}
+}
+
+// parseRaw decodes the change from its plain text description, e.g. Status changed from New to Closed.
+// It is ambiguous when values contain " to ", like "Subject changed from Move to cloud to Stay", so the HTML
+// description is preferred when available. An ambiguous change is decoded without its values.
+func (d *ActivityDetail) parseRaw() {
+	if match := detailChangedRaw.FindStringSubmatch(d.Raw); match != nil {
+		d.Attribute, d.Action = match[1], DetailChanged
+		if values := strings.Split(match[2], " to "); len(values) == 2 {
+			d.OldValue, d.NewValue = values[0], values[1]
+		}
+	} else if match := detailSetRaw.FindStringSubmatch(d.Raw); match != nil {
+		d.Attribute, d.Action, d.NewValue = match[1], DetailSet, match[2]
+	} else if match := detailDeletedRaw.FindStringSubmatch(d.Raw); match != nil {
+		d.Attribute, d.Action, d.OldValue = match[1], DetailDeleted, match[2]
+	}
+}
+
 type Activities struct {
 	Type     string `json:"_type"`
 	Total    int    `json:"total"`
@@ -39,6 +158,11 @@ type Activities struct {
 	} `json:"_links"`
 }
 
+// Elements returns the activities of the list
+func (a *Activities) Elements() []Activity {
+	return a.Embedded.Elements
+}
+
 // GetWithContext gets activity from OpenProject using its ID
 func (s *ActivitiesService) GetWithContext(ctx context.Context, activitiesID string) (*Activity, *Response, error) {
 	apiEndPoint := fmt.Sprintf("api/v3/activities/%s", activitiesID)
@@ -74,3 +198,55 @@ func (s *ActivitiesService) GetFromWPHrefWithContext(ctx context.Context, href s
 func (s *ActivitiesService) GetFromWPHref(href string) (*Activities, *Response, error) {
 	return s.GetFromWPHrefWithContext(context.Background(), href)
 }
+
+// GetByWorkPackageWithContext gets the activities of a work-package, oldest first
+func (s *ActivitiesService) GetByWorkPackageWithContext(ctx context.Context, workpackageID string) (*Activities, *Response, error) {
+	return s.GetFromWPHrefWithContext(ctx, fmt.Sprintf("api/v3/work_packages/%s/activities", workpackageID))
+}
+
+// GetByWorkPackage wraps GetByWorkPackageWithContext using the background context.
+func (s *ActivitiesService) GetByWorkPackage(workpackageID string) (*Activities, *Response, error) {
+	return s.GetByWorkPackageWithContext(context.Background(), workpackageID)
+}
+
+// activityComment is the body of requests adding or editing a comment
+type activityComment struct {
+	Comment OPGenericDescription `json:"comment"`
+}
+
+// UpdateWithContext replaces the comment of an activity with markdown. Users can only edit their own comments,
+// which are the activities with an update link.
+func (s *ActivitiesService) UpdateWithContext(ctx context.Context, activity *Activity, markdown string) (*Activity, *Response, error) {
+	if activity == nil || activity.Links.Update.Href == "" {
+		return nil, nil, errors.New("activity can not be updated, it has no update link")
+	}
+	body := &activityComment{Comment: OPGenericDescription{Raw: markdown}}
+	obj, resp, err := UpdateWithContext(ctx, body, s, strings.TrimPrefix(activity.Links.Update.Href, "/"))
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Activity), resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *ActivitiesService) Update(activity *Activity, markdown string) (*Activity, *Response, error) {
+	return s.UpdateWithContext(context.Background(), activity, markdown)
+}
+
+// AddCommentWithContext comments a work-package with markdown.
+// Users watching or involved in the work-package are notified by email only if notify is true.
+func (s *WorkPackageService) AddCommentWithContext(ctx context.Context, workpackageID string, markdown string, notify bool) (*Activity, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/work_packages/%s/activities?%s", workpackageID,
+		url.Values{"notify": {fmt.Sprint(notify)}}.Encode())
+	body := &activityComment{Comment: OPGenericDescription{Raw: markdown}}
+	obj, resp, err := CreateWithContext(ctx, body, s.client.Activities, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Activity), resp, err
+}
+
+// AddComment wraps AddCommentWithContext using the background context.
+func (s *WorkPackageService) AddComment(workpackageID string, markdown string, notify bool) (*Activity, *Response, error) {
+	return s.AddCommentWithContext(context.Background(), workpackageID, markdown, notify)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"time"
 )
 
 // AttachmentService handles attachments for the OpenProject instance / API.
@@ -50,8 +49,8 @@ type Attachment struct {
 					ChartType string `json:"chartType,omitempty"`
 				} `json:"options,omitempty"`
 			} `json:"widgets,omitempty"`
-			CreatedAt time.Time `json:"createdAt,omitempty"`
-			UpdatedAt time.Time `json:"updatedAt,omitempty"`
+			CreatedAt *Time `json:"createdAt,omitempty"`
+			UpdatedAt *Time `json:"updatedAt,omitempty"`
 			Links     struct {
 				Attachments OPGenericLink `json:"attachments,omitempty"`
 				Scope       OPGenericLink `json:"scope,omitempty"`
@@ -70,7 +69,7 @@ type Attachment struct {
 	} `json:"description,omitempty"`
 	ContentType string           `json:"contentType,omitempty"`
 	Digest      AttachmentDigest `json:"digest,omitempty"`
-	CreatedAt   time.Time        `json:"createdAt,omitempty"`
+	CreatedAt   *Time            `json:"createdAt,omitempty"`
 	Links       struct {
 		Self                   OPGenericLink `json:"self,omitempty"`
 		Author                 OPGenericLink `json:"author,omitempty"`
@@ -111,6 +110,7 @@ func (s *AttachmentService) DownloadWithContext(ctx context.Context, attachmentI
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	respBytes, err := io.ReadAll(resp.Body)
 
@@ -2,9 +2,7 @@ package openproject
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 )
 
@@ -100,7 +98,9 @@ func (s *AuthenticationService) SetBasicAuth(username, password string) {
 	s.authType = authTypeBasic
 }
 
-// Authenticated reports if the current Client has authentication details for OpenProject
+// Authenticated reports if the current Client has authentication details for OpenProject.
+// Credentials configured through SetBasicAuth, session cookies, BasicAuthTransport, APIKeyAuthTransport and OAuth2Transport
+// are taken into account. No request is sent, use GetCurrentUser to verify them against the server.
 func (s *AuthenticationService) Authenticated() bool {
 	if s != nil {
 		if s.authType == authTypeSession {
@@ -108,51 +108,50 @@ func (s *AuthenticationService) Authenticated() bool {
 		} else if s.authType == authTypeBasic {
 			return s.username != ""
 		}
-
+		return s.client.authTransport() != nil
 	}
 	return false
 }
 
-// GetCurrentUserWithContext gets the details of the current user.
-func (s *AuthenticationService) GetCurrentUserWithContext(ctx context.Context) (*Session, error) {
+// GetCurrentUserWithContext gets the details of the current user from api/v3/users/me.
+// It works with every authentication mode, an error matching ErrUnauthenticated is returned
+// if the server does not accept the credentials.
+func (s *AuthenticationService) GetCurrentUserWithContext(ctx context.Context) (*User, error) {
 	if s == nil {
 		return nil, fmt.Errorf("authentication Service is not instantiated")
 	}
-	if s.authType != authTypeSession || s.client.session == nil {
-		return nil, fmt.Errorf("no user is authenticated yet")
-	}
-
-	apiEndpoint := "rest/auth/1/session"
-	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
-	if err != nil {
-		return nil, fmt.Errorf("could not create request for getting user info : %s", err)
-	}
-
-	resp, err := s.client.Do(req, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error sending request to get user info : %s", err)
-	}
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("getting user info failed with status : %d", resp.StatusCode)
-	}
 
-	defer resp.Body.Close()
-	ret := new(Session)
-	data, err := io.ReadAll(resp.Body)
+	apiEndpoint := "api/v3/users/me"
+	obj, _, err := GetWithContext(ctx, s.client.User, apiEndpoint)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't read body from the response : %s", err)
+		return nil, fmt.Errorf("getting user info failed : %w", err)
 	}
 
-	err = json.Unmarshal(data, &ret)
-
-	if err != nil {
-		return nil, fmt.Errorf("could not unmarshall received user info : %s", err)
-	}
-
-	return ret, nil
+	return obj.(*User), nil
 }
 
 // GetCurrentUser wraps GetCurrentUserWithContext using the background context.
-func (s *AuthenticationService) GetCurrentUser() (*Session, error) {
+func (s *AuthenticationService) GetCurrentUser() (*User, error) {
 	return s.GetCurrentUserWithContext(context.Background())
 }
+
+// authTransport returns the authenticating RoundTripper of the underlying http.Client, if any
+func (c *Client) authTransport() http.RoundTripper {
+	httpClient, ok := c.client.(*http.Client)
+	if !ok {
+		return nil
+	}
+	switch tp := httpClient.Transport.(type) {
+	case *BasicAuthTransport:
+		if tp.Username != "" {
+			return tp
+		}
+	case *APIKeyAuthTransport:
+		if tp.APIKey != "" {
+			return tp
+		}
+	case *OAuth2Transport:
+		return tp
+	}
+	return nil
+}
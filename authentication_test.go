@@ -2,6 +2,7 @@ package openproject
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -133,33 +134,31 @@ func TestAuthenticationService_Authenticated_WithBasicAuthButNoUsername(t *testi
 	}
 }
 
+func TestAuthenticationService_Authenticated_WithAPIKeyTransport(t *testing.T) {
+	c, err := NewClientWithAPIKey(testOpenProjectInstanceURL, "secret-token")
+	if err != nil {
+		t.Fatalf("An error occurred. Expected nil. Got %+v.", err)
+	}
+
+	if c.Authentication.Authenticated() != true {
+		t.Error("Expected true, but result was false")
+	}
+}
+
 func TestAithenticationService_GetUserInfo_AccessForbidden_Fail(t *testing.T) {
 	setup()
 	defer teardown()
 	testMux.HandleFunc("/rest/auth/1/session", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == "POST" {
-			testMethod(t, r, "POST")
-			testRequestURL(t, r, "/rest/auth/1/session")
-			b, err := io.ReadAll(r.Body)
-			if err != nil {
-				t.Errorf("Error in read body: %s", err)
-			}
-			if !bytes.Contains(b, []byte(`"username":"foo"`)) {
-				t.Error("No username found")
-			}
-			if !bytes.Contains(b, []byte(`"password":"bar"`)) {
-				t.Error("No password found")
-			}
-
-			fmt.Fprint(w, `{"session":{"name":"JSESSIONID","value":"12345678901234567890"},"loginInfo":{"failedLoginCount":10,"loginCount":127,"lastFailedLoginTime":"2016-03-16T04:22:35.386+0000","previousLoginTime":"2016-03-16T04:22:35.386+0000"}}`)
-		}
+		testMethod(t, r, "POST")
+		testRequestURL(t, r, "/rest/auth/1/session")
 
-		if r.Method == "GET" {
-			testMethod(t, r, "GET")
-			testRequestURL(t, r, "/rest/auth/1/session")
+		fmt.Fprint(w, `{"session":{"name":"JSESSIONID","value":"12345678901234567890"},"loginInfo":{"failedLoginCount":10,"loginCount":127,"lastFailedLoginTime":"2016-03-16T04:22:35.386+0000","previousLoginTime":"2016-03-16T04:22:35.386+0000"}}`)
+	})
+	testMux.HandleFunc("/api/v3/users/me", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/api/v3/users/me")
 
-			w.WriteHeader(http.StatusForbidden)
-		}
+		w.WriteHeader(http.StatusForbidden)
 	})
 
 	testClient.Authentication.AcquireSessionCookie("foo", "bar")
@@ -170,41 +169,22 @@ func TestAithenticationService_GetUserInfo_AccessForbidden_Fail(t *testing.T) {
 	}
 }
 
-func TestAuthenticationService_GetUserInfo_NonOkStatusCode_Fail(t *testing.T) {
+func TestAuthenticationService_GetUserInfo_Unauthenticated_Fail(t *testing.T) {
 	setup()
 	defer teardown()
 
-	testMux.HandleFunc("/rest/auth/1/session", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == "POST" {
-			testMethod(t, r, "POST")
-			testRequestURL(t, r, "/rest/auth/1/session")
-			b, err := io.ReadAll(r.Body)
-			if err != nil {
-				t.Errorf("Error in read body: %s", err)
-			}
-			if !bytes.Contains(b, []byte(`"username":"foo"`)) {
-				t.Error("No username found")
-			}
-			if !bytes.Contains(b, []byte(`"password":"bar"`)) {
-				t.Error("No password found")
-			}
-
-			fmt.Fprint(w, `{"session":{"name":"JSESSIONID","value":"12345678901234567890"},"loginInfo":{"failedLoginCount":10,"loginCount":127,"lastFailedLoginTime":"2016-03-16T04:22:35.386+0000","previousLoginTime":"2016-03-16T04:22:35.386+0000"}}`)
-		}
+	testMux.HandleFunc("/api/v3/users/me", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/api/v3/users/me")
 
-		if r.Method == "GET" {
-			testMethod(t, r, "GET")
-			testRequestURL(t, r, "/rest/auth/1/session")
-			//any status but 200
-			w.WriteHeader(240)
-		}
+		w.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:Unauthenticated","message":"You need to be authenticated to access this resource."}`)
 	})
 
-	testClient.Authentication.AcquireSessionCookie("foo", "bar")
-
 	_, err := testClient.Authentication.GetCurrentUser()
-	if err == nil {
-		t.Errorf("Non nil error expect, received nil")
+	if !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("Expected ErrUnauthenticated, received %v", err)
 	}
 }
 
@@ -222,48 +202,27 @@ func TestAuthenticationService_GetUserInfo_Success(t *testing.T) {
 	setup()
 	defer teardown()
 
-	testUserInfo := new(Session)
-	testUserInfo.Name = "foo"
-	testUserInfo.Self = "https://my.openproject.com/rest/api/latest/user?username=foo"
-	testUserInfo.LoginInfo.FailedLoginCount = 12
-	testUserInfo.LoginInfo.LastFailedLoginTime = "2016-09-06T16:41:23.949+0200"
-	testUserInfo.LoginInfo.LoginCount = 357
-	testUserInfo.LoginInfo.PreviousLoginTime = "2016-09-07T11:36:23.476+0200"
+	apiKey := "secret-token"
+	testMux.HandleFunc("/api/v3/users/me", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/api/v3/users/me")
 
-	testMux.HandleFunc("/rest/auth/1/session", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == "POST" {
-			testMethod(t, r, "POST")
-			testRequestURL(t, r, "/rest/auth/1/session")
-			b, err := io.ReadAll(r.Body)
-			if err != nil {
-				t.Errorf("Error in read body: %s", err)
-			}
-			if !bytes.Contains(b, []byte(`"username":"foo"`)) {
-				t.Error("No username found")
-			}
-			if !bytes.Contains(b, []byte(`"password":"bar"`)) {
-				t.Error("No password found")
-			}
-
-			fmt.Fprint(w, `{"session":{"name":"JSESSIONID","value":"12345678901234567890"},"loginInfo":{"failedLoginCount":10,"loginCount":127,"lastFailedLoginTime":"2016-03-16T04:22:35.386+0000","previousLoginTime":"2016-03-16T04:22:35.386+0000"}}`)
+		u, p, ok := r.BasicAuth()
+		if !ok || u != "apikey" || p != apiKey {
+			t.Errorf("Expected API key basic auth. Got username %q and password %q", u, p)
 		}
 
-		if r.Method == "GET" {
-			testMethod(t, r, "GET")
-			testRequestURL(t, r, "/rest/auth/1/session")
-			fmt.Fprint(w, `{"self":"https://my.openproject.com/rest/api/latest/user?username=foo","name":"foo","loginInfo":{"failedLoginCount":12,"loginCount":357,"lastFailedLoginTime":"2016-09-06T16:41:23.949+0200","previousLoginTime":"2016-09-07T11:36:23.476+0200"}}`)
-		}
+		fmt.Fprint(w, `{"_type":"User","id":5,"name":"Foo Bar","login":"foo","firstName":"Foo","lastName":"Bar","status":"active"}`)
 	})
 
-	testClient.Authentication.AcquireSessionCookie("foo", "bar")
-
-	userinfo, err := testClient.Authentication.GetCurrentUser()
+	c, _ := NewClientWithAPIKey(testServer.URL, apiKey)
+	userinfo, err := c.Authentication.GetCurrentUser()
 	if err != nil {
-		t.Errorf("Nil error expect, received %s", err)
+		t.Fatalf("Nil error expect, received %s", err)
 	}
-	equal := reflect.DeepEqual(*testUserInfo, *userinfo)
 
-	if !equal {
-		t.Error("The user information doesn't match")
+	testUserInfo := &User{Type: "User", ID: 5, Name: "Foo Bar", Login: "foo", FirstName: "Foo", LastName: "Bar", Status: "active"}
+	if !reflect.DeepEqual(testUserInfo, userinfo) {
+		t.Errorf("The user information doesn't match. Expected %+v, got %+v", testUserInfo, userinfo)
 	}
 }
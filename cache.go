@@ -0,0 +1,357 @@
+package openproject
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fromCacheHeader is set on responses served from the cache
+const fromCacheHeader = "X-From-Cache"
+
+// CachedResponse is a response stored by a CacheStore
+type CachedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	// ETag of the response, used to revalidate it with If-None-Match
+	ETag string `json:"etag,omitempty"`
+	// Expires is the time until which the response is served without revalidation, zero if it must always be revalidated
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+// CacheStore stores cached responses. Implementations must be safe for concurrent use.
+type CacheStore interface {
+	// Get returns the response stored for key, if any
+	Get(key string) (*CachedResponse, bool)
+	// Set stores the response for key
+	Set(key string, response *CachedResponse)
+	// Delete removes the response stored for key
+	Delete(key string)
+}
+
+// responseCache caches GET responses of a Client
+type responseCache struct {
+	store CacheStore
+	// ttls are the times responses of templated endpoints are served without revalidation
+	ttls map[string]time.Duration
+
+	// oauth2User is the ID of the user authenticated by the OAuth2 transport during oauth2Session
+	mu            sync.Mutex
+	oauth2User    string
+	oauth2Session uint64
+}
+
+// WithCache caches the responses of GET requests in store, keyed by URL and authenticated identity.
+// Responses carrying an ETag are revalidated with If-None-Match and served from the cache on 304 Not Modified.
+// With OAuth2 the identity is the user of the token, looked up once through api/v3/users/me, so refreshing tokens keeps the cache.
+// Downloads are never cached. Modifying a resource (POST, PATCH, PUT, DELETE) through the Client evicts its cached response.
+func WithCache(store CacheStore) ClientOption {
+	return func(c *Client) {
+		c.ensureCache().store = store
+	}
+}
+
+// WithCacheTTL serves the responses of endpoint from the cache for ttl without contacting OpenProject,
+// which suits reference data changing rarely, like statuses or categories.
+// endpoint is a templated endpoint as reported to instrumentation, e.g. api/v3/statuses or api/v3/projects/{id}/categories.
+// It only takes effect along with WithCache.
+func WithCacheTTL(endpoint string, ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.ensureCache().ttls[strings.Trim(endpoint, "/")] = ttl
+	}
+}
+
+// ensureCache returns the response cache of the client, creating it if needed
+func (c *Client) ensureCache() *responseCache {
+	if c.cache == nil {
+		c.cache = &responseCache{ttls: make(map[string]time.Duration)}
+	}
+	return c.cache
+}
+
+// sendCached sends an API request, serving it from the cache when possible
+func (c *Client) sendCached(req *http.Request) (*http.Response, error) {
+	if c.cache == nil || c.cache.store == nil {
+		return c.send(req)
+	}
+	key, ok := c.cacheKey(req)
+	if !ok {
+		return c.send(req)
+	}
+	store := c.cache.store
+	if req.Method != http.MethodGet {
+		store.Delete(key)
+		return c.send(req)
+	}
+
+	_, endpoint := c.endpointTemplate(req.URL.Path)
+	ttl := c.cache.ttls[endpoint]
+	cached, found := store.Get(key)
+	if found && !cached.Expires.IsZero() && time.Now().Before(cached.Expires) {
+		return cached.response(req), nil
+	}
+	if found && cached.ETag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode == http.StatusNotModified && found {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if ttl > 0 {
+			cached.Expires = time.Now().Add(ttl)
+			store.Set(key, cached)
+		}
+		return cached.response(req), nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || (etag == "" && ttl <= 0) ||
+		strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	entry := &CachedResponse{StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: body, ETag: etag}
+	if ttl > 0 {
+		entry.Expires = time.Now().Add(ttl)
+	}
+	store.Set(key, entry)
+	return resp, nil
+}
+
+// cacheKey returns the key of the cached response of req: its URL and a hash of the identity sending it.
+// OAuth2 tokens change on every refresh, so they are identified by the application and the user they authenticate.
+// It returns false if the identity can not be determined.
+func (c *Client) cacheKey(req *http.Request) (string, bool) {
+	identity := sha256.New()
+	switch tp := c.authTransport().(type) {
+	case *BasicAuthTransport:
+		io.WriteString(identity, "basic\x00"+tp.Username+"\x00"+tp.Password+"\x00")
+	case *APIKeyAuthTransport:
+		io.WriteString(identity, "apikey\x00"+tp.APIKey+"\x00")
+	case *OAuth2Transport:
+		userID, err := c.oauth2User(req.Context(), tp)
+		if err != nil {
+			return "", false
+		}
+		io.WriteString(identity, "oauth2\x00"+tp.TokenURL+"\x00"+tp.ClientID+"\x00"+userID+"\x00")
+	}
+	// Credentials set on the request itself, like session cookies
+	io.WriteString(identity, req.Header.Get("Authorization")+"\x00"+req.Header.Get("Cookie"))
+	return hex.EncodeToString(identity.Sum(nil)) + " " + req.URL.String(), true
+}
+
+// oauth2User returns the ID of the user authenticated by tp, asking OpenProject once per token session
+func (c *Client) oauth2User(ctx context.Context, tp *OAuth2Transport) (string, error) {
+	session := tp.currentSession()
+	c.cache.mu.Lock()
+	if c.cache.oauth2User != "" && c.cache.oauth2Session == session {
+		userID := c.cache.oauth2User
+		c.cache.mu.Unlock()
+		return userID, nil
+	}
+	c.cache.mu.Unlock()
+
+	// Sent past the cache and the instrumentation of the request being served
+	req, err := c.NewRequestWithContext(ctx, "GET", "api/v3/users/me", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp); err != nil {
+		return "", err
+	}
+	var me struct {
+		ID int `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&me); err != nil {
+		return "", err
+	}
+
+	userID := strconv.Itoa(me.ID)
+	c.cache.mu.Lock()
+	c.cache.oauth2User = userID
+	c.cache.oauth2Session = session
+	c.cache.mu.Unlock()
+	return userID, nil
+}
+
+// response returns a new http.Response serving the cached response to req
+func (r *CachedResponse) response(req *http.Request) *http.Response {
+	header := r.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(fromCacheHeader, "1")
+	return &http.Response{
+		Status:        strconv.Itoa(r.StatusCode) + " " + http.StatusText(r.StatusCode),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// LRUCacheStore is an in-memory CacheStore evicting the least recently used responses
+type LRUCacheStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+// lruEntry is an element of the LRUCacheStore order list
+type lruEntry struct {
+	key      string
+	response *CachedResponse
+}
+
+// NewLRUCacheStore returns an in-memory CacheStore holding up to maxEntries responses, unlimited if 0
+func NewLRUCacheStore(maxEntries int) *LRUCacheStore {
+	return &LRUCacheStore{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get returns the response stored for key, if any
+func (s *LRUCacheStore) Get(key string) (*CachedResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	s.order.MoveToFront(element)
+	response := *element.Value.(*lruEntry).response
+	return &response, true
+}
+
+// Set stores the response for key, evicting the least recently used one if the store is full
+func (s *LRUCacheStore) Set(key string, response *CachedResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *response
+	if element, ok := s.entries[key]; ok {
+		element.Value.(*lruEntry).response = &stored
+		s.order.MoveToFront(element)
+		return
+	}
+	s.entries[key] = s.order.PushFront(&lruEntry{key: key, response: &stored})
+	if s.maxEntries > 0 && s.order.Len() > s.maxEntries {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Delete removes the response stored for key
+func (s *LRUCacheStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[key]; ok {
+		s.order.Remove(element)
+		delete(s.entries, key)
+	}
+}
+
+// Len returns the number of stored responses
+func (s *LRUCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+// FileCacheStore is a CacheStore keeping every response in a JSON file of a directory,
+// so the cache survives restarts and can be shared by several processes.
+// Files that can not be read or written are treated as cache misses.
+type FileCacheStore struct {
+	dir string
+}
+
+// NewFileCacheStore returns a CacheStore keeping responses in dir, which is created if needed
+func NewFileCacheStore(dir string) (*FileCacheStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileCacheStore{dir: dir}, nil
+}
+
+// Get returns the response stored for key, if any
+func (s *FileCacheStore) Get(key string) (*CachedResponse, bool) {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return nil, false
+	}
+	response := &CachedResponse{}
+	if err := json.Unmarshal(data, response); err != nil {
+		return nil, false
+	}
+	return response, true
+}
+
+// Set stores the response for key. The file is replaced atomically so concurrent readers never see partial writes.
+func (s *FileCacheStore) Set(key string, response *CachedResponse) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(s.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// Delete removes the response stored for key
+func (s *FileCacheStore) Delete(key string) {
+	os.Remove(s.path(key))
+}
+
+// path returns the file holding the response of key
+func (s *FileCacheStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,203 @@
+package openproject
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestClient_Cache_ETag(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests, notModified := 0, 0
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+
+	c, _ := NewClient(nil, testServer.URL, WithCache(NewLRUCacheStore(10)))
+	for i := 0; i < 3; i++ {
+		status, resp, err := c.Status.Get("1")
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if status.Name != "New" {
+			t.Errorf("Expected status New. Got %s", status.Name)
+		}
+		if fromCache := resp.Header.Get("X-From-Cache") != ""; fromCache != (i > 0) {
+			t.Errorf("Request %d: unexpected X-From-Cache header %q", i, resp.Header.Get("X-From-Cache"))
+		}
+	}
+	if requests != 3 || notModified != 2 {
+		t.Errorf("Expected 3 requests, 2 revalidated. Got %d requests, %d revalidated", requests, notModified)
+	}
+}
+
+func TestClient_Cache_TTL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	testMux.HandleFunc("/api/v3/statuses", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		raw, _ := os.ReadFile("./mocks/get/get-statuses-no-filters.json")
+		fmt.Fprint(w, string(raw))
+	})
+
+	c, _ := NewClient(nil, testServer.URL, WithCache(NewLRUCacheStore(10)), WithCacheTTL("api/v3/statuses", time.Hour))
+	for i := 0; i < 3; i++ {
+		statuses, _, err := c.Status.GetList(1, 100)
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if len(statuses.Embedded.Elements) == 0 {
+			t.Error("Expected statuses from the cache")
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected a single request. Got %d", requests)
+	}
+}
+
+func TestClient_Cache_Identity(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+
+	store := NewLRUCacheStore(10)
+	for _, apiKey := range []string{"alice", "bob", "alice"} {
+		tp := &APIKeyAuthTransport{APIKey: apiKey}
+		c, _ := NewClient(tp.Client(), testServer.URL, WithCache(store), WithCacheTTL("api/v3/statuses/{id}", time.Hour))
+		if _, _, err := c.Status.Get("1"); err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected a request per API key. Got %d", requests)
+	}
+}
+
+func TestClient_Cache_OAuth2IdentitySurvivesRefresh(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tokens := 0
+	testMux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		tokens++
+		// Tokens expire right away, so each request refreshes it
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":1,"refresh_token":"refresh-%d"}`, tokens, tokens)
+	})
+	meRequests := 0
+	testMux.HandleFunc("/api/v3/users/me", func(w http.ResponseWriter, r *http.Request) {
+		meRequests++
+		if r.Header.Get("Authorization") == "Bearer other-user" {
+			fmt.Fprint(w, `{"_type":"User","id":9}`)
+			return
+		}
+		fmt.Fprint(w, `{"_type":"User","id":5}`)
+	})
+	requests := 0
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+
+	tp := &OAuth2Transport{TokenURL: testServer.URL + "/oauth/token", ClientID: "client-id", RefreshToken: "refresh-0"}
+	c, _ := NewClient(tp.Client(), testServer.URL, WithCache(NewLRUCacheStore(10)), WithCacheTTL("api/v3/statuses/{id}", time.Hour))
+	for i := 0; i < 3; i++ {
+		if _, _, err := c.Status.Get("1"); err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+	}
+	if requests != 1 || meRequests != 1 {
+		t.Errorf("Expected a single request and user lookup across refreshes. Got %d requests, %d lookups", requests, meRequests)
+	}
+
+	tp.SetToken(&OAuth2Token{AccessToken: "other-user"})
+	if _, _, err := c.Status.Get("1"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if requests != 2 || meRequests != 2 {
+		t.Errorf("Expected another user not to be served the cached response. Got %d requests, %d lookups", requests, meRequests)
+	}
+}
+
+func TestClient_Cache_InvalidatedByUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	subject := "Before"
+	testMux.HandleFunc("/api/v3/work_packages/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			subject = "After"
+		}
+		fmt.Fprintf(w, `{"_type":"WorkPackage","id":1,"subject":%q,"lockVersion":1}`, subject)
+	})
+
+	c, _ := NewClient(nil, testServer.URL, WithCache(NewLRUCacheStore(10)), WithCacheTTL("api/v3/work_packages/{id}", time.Hour))
+	if _, _, err := c.WorkPackage.Get("1"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := c.WorkPackage.Update("1", &WorkPackagePatch{Subject: Ptr("After"), LockVersion: 1}); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	wp, _, err := c.WorkPackage.Get("1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if wp.Subject != "After" {
+		t.Errorf("Expected the cached work package to be evicted by the update. Got subject %s", wp.Subject)
+	}
+}
+
+func TestLRUCacheStore_Eviction(t *testing.T) {
+	store := NewLRUCacheStore(2)
+	store.Set("a", &CachedResponse{Body: []byte("a")})
+	store.Set("b", &CachedResponse{Body: []byte("b")})
+	store.Get("a")
+	store.Set("c", &CachedResponse{Body: []byte("c")})
+
+	if _, ok := store.Get("b"); ok {
+		t.Error("Expected the least recently used entry to be evicted")
+	}
+	if _, ok := store.Get("a"); !ok {
+		t.Error("Expected the recently used entry to be kept")
+	}
+	if store.Len() != 2 {
+		t.Errorf("Expected 2 entries. Got %d", store.Len())
+	}
+}
+
+func TestFileCacheStore(t *testing.T) {
+	store, err := NewFileCacheStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	if _, ok := store.Get("key"); ok {
+		t.Error("Expected a cache miss")
+	}
+	store.Set("key", &CachedResponse{StatusCode: http.StatusOK, Body: []byte(`{"id":1}`), ETag: `"v1"`})
+	cached, ok := store.Get("key")
+	if !ok || string(cached.Body) != `{"id":1}` || cached.ETag != `"v1"` {
+		t.Errorf("Expected the stored response. Got %+v", cached)
+	}
+	store.Delete("key")
+	if _, ok := store.Get("key"); ok {
+		t.Error("Expected the response to be deleted")
+	}
+}
@@ -0,0 +1,126 @@
+package openproject
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// isoDurationPattern matches the ISO 8601 durations used by OpenProject, like PT2H30M, P1DT4H or PT0.5H.
+// Years and months are not supported since their length is not fixed.
+var isoDurationPattern = regexp.MustCompile(
+	`^([-+])?P(?:([0-9.,]+)W)?(?:([0-9.,]+)D)?(?:T(?:([0-9.,]+)H)?(?:([0-9.,]+)M)?(?:([0-9.,]+)S)?)?$`)
+
+// isoDurationUnits are the lengths of the units captured by isoDurationPattern, in order
+var isoDurationUnits = []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+// ParseISODuration parses an ISO 8601 duration as returned by OpenProject, like PT2H30M or P1DT0.5H, into a time.Duration.
+// A day is 24 hours and a week 7 days.
+func ParseISODuration(s string) (time.Duration, error) {
+	match := isoDurationPattern.FindStringSubmatch(s)
+	if match == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+
+	var nanoseconds float64
+	for i, unit := range isoDurationUnits {
+		value := match[i+2]
+		if value == "" {
+			continue
+		}
+		number, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q: %w", s, err)
+		}
+		nanoseconds += number * float64(unit)
+	}
+	if nanoseconds > math.MaxInt64 {
+		return 0, fmt.Errorf("ISO 8601 duration %q overflows", s)
+	}
+	d := time.Duration(math.Round(nanoseconds))
+	if match[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// FormatISODuration formats d as an ISO 8601 duration in hours, minutes and seconds, like PT2H30M.
+// It is the format OpenProject expects for durations like time entry hours.
+func FormatISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	b.WriteString("PT")
+	if hours := d / time.Hour; hours > 0 {
+		b.WriteString(strconv.FormatInt(int64(hours), 10) + "H")
+		d -= hours * time.Hour
+	}
+	if minutes := d / time.Minute; minutes > 0 {
+		b.WriteString(strconv.FormatInt(int64(minutes), 10) + "M")
+		d -= minutes * time.Minute
+	}
+	if d > 0 {
+		b.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S")
+	}
+	return b.String()
+}
+
+// Duration represents the ISO 8601 durations of OpenProject, like estimated time or time entry hours, as a time.Duration of go.
+// It is marshalled in hours, minutes and seconds, e.g. P1DT0.5H is read as 24h30m and written back as PT24H30M.
+type Duration time.Duration
+
+// NewDuration returns a pointer to d as a Duration, to fill optional duration fields like WorkPackage.EstimatedTime
+func NewDuration(d time.Duration) *Duration {
+	duration := Duration(d)
+	return &duration
+}
+
+// Duration returns d as a time.Duration
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// Hours returns d as a floating point number of hours
+func (d Duration) Hours() float64 {
+	return time.Duration(d).Hours()
+}
+
+// String returns d as an ISO 8601 duration in hours, minutes and seconds.
+// It does not keep the form OpenProject sent: days and fractions are normalised, P1DT0.5H becomes PT24H30M.
+func (d Duration) String() string {
+	return FormatISODuration(time.Duration(d))
+}
+
+// UnmarshalJSON will transform the OpenProject duration into a Duration
+// during the transformation of the OpenProject JSON response
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(b) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseISODuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON will transform the Duration into an OpenProject duration
+// during the creation of a OpenProject request
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
@@ -0,0 +1,106 @@
+package openproject
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"PT0S", 0},
+		{"PT2H30M", 2*time.Hour + 30*time.Minute},
+		{"PT1.5H", 90 * time.Minute},
+		{"PT0,25H", 15 * time.Minute},
+		{"P1DT2H", 26 * time.Hour},
+		{"P1W", 7 * 24 * time.Hour},
+		{"PT45S", 45 * time.Second},
+		{"-PT1H", -time.Hour},
+	}
+	for _, test := range tests {
+		got, err := ParseISODuration(test.in)
+		if err != nil {
+			t.Errorf("ParseISODuration(%q): %s", test.in, err)
+		} else if got != test.want {
+			t.Errorf("ParseISODuration(%q) = %s, want %s", test.in, got, test.want)
+		}
+	}
+
+	for _, in := range []string{"", "P", "PT", "2h", "PT1.2.3H", "P1Y"} {
+		if _, err := ParseISODuration(in); err == nil {
+			t.Errorf("ParseISODuration(%q): expected error", in)
+		}
+	}
+}
+
+func TestFormatISODuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                            "PT0S",
+		2*time.Hour + 30*time.Minute: "PT2H30M",
+		26 * time.Hour:               "PT26H",
+		90 * time.Second:             "PT1M30S",
+		1500 * time.Millisecond:      "PT1.5S",
+		-time.Hour:                   "-PT1H",
+	}
+	for in, want := range tests {
+		if got := FormatISODuration(in); got != want {
+			t.Errorf("FormatISODuration(%s) = %s, want %s", in, got, want)
+		}
+		if back, err := ParseISODuration(FormatISODuration(in)); err != nil || back != in {
+			t.Errorf("ParseISODuration(FormatISODuration(%s)) = %s, %v", in, back, err)
+		}
+	}
+}
+
+func TestDuration_JSON(t *testing.T) {
+	var wp WorkPackage
+	raw := `{"estimatedTime":"P1DT0.5H","remainingTime":"PT1H15M","spentTime":"PT0S","derivedEstimatedTime":null}`
+	if err := json.Unmarshal([]byte(raw), &wp); err != nil {
+		t.Fatal(err)
+	}
+	if wp.EstimatedTime.Duration() != 24*time.Hour+30*time.Minute {
+		t.Errorf("Expected estimated time 24h30m. Got %s", wp.EstimatedTime.Duration())
+	}
+	if wp.DerivedEstimatedTime != nil {
+		t.Errorf("Expected no derived estimated time. Got %s", wp.DerivedEstimatedTime)
+	}
+	if remaining := wp.EstimatedTime.Duration() - wp.RemainingTime.Duration(); remaining != 23*time.Hour+15*time.Minute {
+		t.Errorf("Expected 23h15m done. Got %s", remaining)
+	}
+
+	out, err := json.Marshal(&wp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back WorkPackage
+	if err := json.Unmarshal(out, &back); err != nil {
+		t.Fatal(err)
+	}
+	if *back.EstimatedTime != *wp.EstimatedTime || *back.RemainingTime != *wp.RemainingTime || *back.SpentTime != 0 {
+		t.Errorf("Round trip changed durations: %s", out)
+	}
+	if got := wp.EstimatedTime.String(); got != "PT24H30M" {
+		t.Errorf("Expected P1DT0.5H to be normalised to PT24H30M. Got %s", got)
+	}
+	if want := `"estimatedTime":"PT24H30M"`; !strings.Contains(string(out), want) {
+		t.Errorf("Expected %s in %s", want, out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"estimatedTime":"2h"}`), &wp); err == nil {
+		t.Error("Expected error on invalid duration")
+	}
+}
+
+func TestWPSums_Duration(t *testing.T) {
+	sums := WPSums{"estimatedTime": "PT4H", "storyPoints": float64(7)}
+	if d, ok := sums.Duration("estimatedTime"); !ok || d.Hours() != 4 {
+		t.Errorf("Expected 4 hours estimated. Got %s %v", d, ok)
+	}
+	if _, ok := sums.Duration("storyPoints"); ok {
+		t.Error("Expected story points not to be a duration")
+	}
+}
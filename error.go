@@ -6,14 +6,81 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"io"
+	"net/http"
 	"strings"
 )
 
+// errorIdentifierPrefix is the common prefix of every OpenProject errorIdentifier
+// Doc. https://www.openproject.org/docs/api/errors/
+const errorIdentifierPrefix = "urn:openproject-org:api:v3:errors:"
+
+// Sentinel errors to be used with errors.Is against errors returned by the client
+var (
+	// ErrNotFound resource does not exist or the user is not allowed to see it
+	ErrNotFound = errors.New("openproject: not found")
+	// ErrUnauthenticated request lacks valid authentication
+	ErrUnauthenticated = errors.New("openproject: unauthenticated")
+	// ErrMissingPermission authenticated user is not allowed to perform the action
+	ErrMissingPermission = errors.New("openproject: missing permission")
+	// ErrConflict resource was modified in the meantime (outdated lockVersion)
+	ErrConflict = errors.New("openproject: conflict")
+	// ErrPropertyIsReadOnly request tried to modify a read-only property
+	ErrPropertyIsReadOnly = errors.New("openproject: property is read only")
+	// ErrPropertyConstraintViolation request sent a value not allowed for a property
+	ErrPropertyConstraintViolation = errors.New("openproject: property constraint violation")
+	// ErrPropertyFormat request sent a property with a wrong format
+	ErrPropertyFormat = errors.New("openproject: property format error")
+	// ErrPropertyMissing request did not send a required property
+	ErrPropertyMissing = errors.New("openproject: property missing")
+	// ErrInvalidQuery request sent invalid filters, sorting or grouping parameters
+	ErrInvalidQuery = errors.New("openproject: invalid query")
+	// ErrInvalidRequestBody request body could not be parsed
+	ErrInvalidRequestBody = errors.New("openproject: invalid request body")
+)
+
+// errorIdentifiers maps errorIdentifier suffixes to sentinel errors
+var errorIdentifiers = map[string]error{
+	"NotFound":                    ErrNotFound,
+	"Unauthenticated":             ErrUnauthenticated,
+	"MissingPermission":           ErrMissingPermission,
+	"UpdateConflict":              ErrConflict,
+	"Conflict":                    ErrConflict,
+	"PropertyIsReadOnly":          ErrPropertyIsReadOnly,
+	"PropertyConstraintViolation": ErrPropertyConstraintViolation,
+	"PropertyFormatError":         ErrPropertyFormat,
+	"PropertyMissingError":        ErrPropertyMissing,
+	"InvalidQuery":                ErrInvalidQuery,
+	"InvalidRequestBody":          ErrInvalidRequestBody,
+}
+
+// statusCodeErrors maps HTTP status codes to sentinel errors for responses without errorIdentifier
+var statusCodeErrors = map[int]error{
+	http.StatusNotFound:     ErrNotFound,
+	http.StatusUnauthorized: ErrUnauthenticated,
+	http.StatusForbidden:    ErrMissingPermission,
+	http.StatusConflict:     ErrConflict,
+}
+
 // Error message from OpenProject
+// Doc. https://www.openproject.org/docs/api/errors/
 type Error struct {
-	HTTPError     error
-	ErrorMessages []string          `json:"errorMessages"`
-	Errors        map[string]string `json:"errors"`
+	HTTPError  error         `json:"-"`
+	StatusCode int           `json:"-"`
+	Type       string        `json:"_type,omitempty"`
+	Identifier string        `json:"errorIdentifier,omitempty"`
+	Message    string        `json:"message,omitempty"`
+	Embedded   ErrorEmbedded `json:"_embedded,omitempty"`
+}
+
+// ErrorEmbedded wraps embedded fields of Error
+type ErrorEmbedded struct {
+	Details *ErrorDetails `json:"details,omitempty"`
+	Errors  []*Error      `json:"errors,omitempty"`
+}
+
+// ErrorDetails identifies the attribute an error refers to
+type ErrorDetails struct {
+	Attribute string `json:"attribute,omitempty"`
 }
 
 // NewOpenProjectError creates a new OpenProject Error
@@ -22,14 +89,19 @@ func NewOpenProjectError(resp *Response, httpError error) error {
 		return errors.Wrap(httpError, "No response returned")
 	}
 
+	// CheckResponse already decoded the error
+	var opErr *Error
+	if errors.As(httpError, &opErr) {
+		return httpError
+	}
+
 	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return errors.Wrap(err, httpError.Error())
 	}
-	jerr := Error{HTTPError: httpError}
-	contentType := resp.Header.Get("Content-Type")
-	if strings.HasPrefix(contentType, "application/json") {
+	jerr := Error{HTTPError: httpError, StatusCode: resp.StatusCode}
+	if isJSONContentType(resp.Header.Get("Content-Type")) {
 		err = json.Unmarshal(body, &jerr)
 		if err != nil {
 			httpError = errors.Wrap(errors.New("could not parse JSON"), httpError.Error())
@@ -45,18 +117,31 @@ func NewOpenProjectError(resp *Response, httpError error) error {
 	return &jerr
 }
 
+// isJSONContentType reports whether the content type is JSON (application/json or application/hal+json)
+func isJSONContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "application/json") || strings.HasPrefix(contentType, "application/hal+json")
+}
+
 // Error is a short string representing the error
 func (e *Error) Error() string {
-	if len(e.ErrorMessages) > 0 {
-		// return fmt.Sprintf("%v", e.HTTPError)
-		return fmt.Sprintf("%s: %v", e.ErrorMessages[0], e.HTTPError)
+	msg := e.Message
+	if len(e.Embedded.Errors) > 0 {
+		messages := make([]string, 0, len(e.Embedded.Errors))
+		for _, embedded := range e.Embedded.Errors {
+			messages = append(messages, embedded.Message)
+		}
+		msg = fmt.Sprintf("%s (%s)", msg, strings.Join(messages, "; "))
 	}
-	if len(e.Errors) > 0 {
-		for key, value := range e.Errors {
-			return fmt.Sprintf("%s - %s: %v", key, value, e.HTTPError)
+	if msg == "" {
+		if e.HTTPError == nil {
+			return fmt.Sprintf("OpenProject error (status code %d)", e.StatusCode)
 		}
+		return e.HTTPError.Error()
 	}
-	return e.HTTPError.Error()
+	if e.HTTPError == nil {
+		return msg
+	}
+	return fmt.Sprintf("%s: %v", msg, e.HTTPError)
 }
 
 // LongError is a full representation of the error as a string
@@ -67,22 +152,101 @@ func (e *Error) LongError() string {
 		msg.WriteString(e.HTTPError.Error())
 		msg.WriteString("\n")
 	}
-	if len(e.ErrorMessages) > 0 {
-		msg.WriteString("Messages:\n")
-		for _, v := range e.ErrorMessages {
-			msg.WriteString(" - ")
-			msg.WriteString(v)
-			msg.WriteString("\n")
-		}
+	if e.Identifier != "" {
+		msg.WriteString("Identifier:\n")
+		msg.WriteString(e.Identifier)
+		msg.WriteString("\n")
 	}
-	if len(e.Errors) > 0 {
-		for key, value := range e.Errors {
-			msg.WriteString(" - ")
-			msg.WriteString(key)
+	if e.Message != "" {
+		msg.WriteString("Message:\n")
+		msg.WriteString(e.Message)
+		msg.WriteString("\n")
+	}
+	if len(e.Embedded.Errors) > 0 {
+		msg.WriteString("Errors:\n")
+		for _, embedded := range e.Embedded.Errors {
 			msg.WriteString(" - ")
-			msg.WriteString(value)
+			if attribute := embedded.Attribute(); attribute != "" {
+				msg.WriteString(attribute)
+				msg.WriteString(" - ")
+			}
+			msg.WriteString(embedded.Message)
 			msg.WriteString("\n")
 		}
 	}
 	return msg.String()
 }
+
+// Unwrap returns the error returned by the request
+func (e *Error) Unwrap() error {
+	return e.HTTPError
+}
+
+// Is allows comparing the error with sentinel errors like ErrNotFound or ErrConflict using errors.Is.
+// A response holding multiple errors matches every sentinel of its embedded errors.
+func (e *Error) Is(target error) bool {
+	if e.Identifier != "" {
+		if errorIdentifiers[strings.TrimPrefix(e.Identifier, errorIdentifierPrefix)] == target {
+			return true
+		}
+	} else if e.StatusCode != 0 && statusCodeErrors[e.StatusCode] == target {
+		return true
+	}
+	for _, embedded := range e.Embedded.Errors {
+		if embedded.Is(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Attribute returns the name of the attribute the error refers to, if any
+func (e *Error) Attribute() string {
+	if e.Embedded.Details == nil {
+		return ""
+	}
+	return e.Embedded.Details.Attribute
+}
+
+// ValidationErrors returns error messages grouped by attribute.
+// It covers both single errors with details and responses holding multiple errors.
+func (e *Error) ValidationErrors() map[string][]string {
+	result := make(map[string][]string)
+	if attribute := e.Attribute(); attribute != "" {
+		result[attribute] = append(result[attribute], e.Message)
+	}
+	for _, embedded := range e.Embedded.Errors {
+		for attribute, messages := range embedded.ValidationErrors() {
+			result[attribute] = append(result[attribute], messages...)
+		}
+	}
+	return result
+}
+
+// ConflictError is returned when OpenProject rejects a modification with HTTP 409 Conflict.
+// This usually means the lockVersion sent along with the changes is outdated because
+// somebody else modified the resource in the meantime.
+type ConflictError struct {
+	// LockVersion sent within the rejected request
+	LockVersion int
+	// HTTPError is the error returned by the request
+	HTTPError error
+}
+
+// Error is a short string representing the error
+func (e *ConflictError) Error() string {
+	if e.HTTPError == nil {
+		return fmt.Sprintf("update conflict (lockVersion %d)", e.LockVersion)
+	}
+	return fmt.Sprintf("update conflict (lockVersion %d): %v", e.LockVersion, e.HTTPError)
+}
+
+// Unwrap returns the error returned by the request
+func (e *ConflictError) Unwrap() error {
+	return e.HTTPError
+}
+
+// Is reports ConflictError as ErrConflict
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
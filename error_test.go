@@ -3,6 +3,7 @@ package openproject
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"testing"
@@ -14,7 +15,7 @@ func TestError_NewOpenProjectError(t *testing.T) {
 
 	testMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprint(w, `{"errorMessages":["Workpackage does not exist or you do not have permission to see it."],"errors":{}}`)
+		fmt.Fprint(w, `{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:NotFound","message":"Workpackage does not exist or you do not have permission to see it."}`)
 	})
 
 	req, _ := testClient.NewRequest("GET", "/", nil)
@@ -101,6 +102,29 @@ func TestError_BadJSON(t *testing.T) {
 	}
 }
 
+func testMultipleErrors() *Error {
+	return &Error{
+		HTTPError:  errors.New("Original http error"),
+		Type:       "Error",
+		Identifier: "urn:openproject-org:api:v3:errors:MultipleErrors",
+		Message:    "Multiple field constraints have been violated.",
+		Embedded: ErrorEmbedded{
+			Errors: []*Error{
+				{
+					Identifier: "urn:openproject-org:api:v3:errors:PropertyConstraintViolation",
+					Message:    "Subject can't be blank.",
+					Embedded:   ErrorEmbedded{Details: &ErrorDetails{Attribute: "subject"}},
+				},
+				{
+					Identifier: "urn:openproject-org:api:v3:errors:PropertyIsReadOnly",
+					Message:    "ID was attempted to be written but is not writable.",
+					Embedded:   ErrorEmbedded{Details: &ErrorDetails{Attribute: "id"}},
+				},
+			},
+		},
+	}
+}
+
 func TestError_NilOriginalMessage(t *testing.T) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -108,16 +132,11 @@ func TestError_NilOriginalMessage(t *testing.T) {
 		}
 	}()
 
-	msgErr := &Error{
-		HTTPError:     nil,
-		ErrorMessages: []string{"WorkPackage does not exist"},
-		Errors: map[string]string{
-			"workpackagetype": "WorkPackage type is required",
-			"title":           "title is required",
-		},
-	}
+	msgErr := testMultipleErrors()
+	msgErr.HTTPError = nil
 
 	_ = msgErr.Error()
+	_ = (&Error{}).Error()
 }
 
 func TestError_NilOriginalMessageLongError(t *testing.T) {
@@ -127,41 +146,22 @@ func TestError_NilOriginalMessageLongError(t *testing.T) {
 		}
 	}()
 
-	msgErr := &Error{
-		HTTPError:     nil,
-		ErrorMessages: []string{"WorkPackage does not exist"},
-		Errors: map[string]string{
-			"workpackagetype": "WorkPackage type is required",
-			"title":           "title is required",
-		},
-	}
+	msgErr := testMultipleErrors()
+	msgErr.HTTPError = nil
 
 	_ = msgErr.LongError()
 }
 
 func TestError_ShortMessage(t *testing.T) {
 	msgErr := &Error{
-		HTTPError:     errors.New("Original http error"),
-		ErrorMessages: []string{"WorkPackage does not exist"},
-		Errors: map[string]string{
-			"workpackagetype": "WorkPackage type is required",
-			"title":           "title is required",
-		},
+		HTTPError: errors.New("Original http error"),
+		Message:   "WorkPackage does not exist",
 	}
 
-	mapErr := &Error{
-		HTTPError:     errors.New("Original http error"),
-		ErrorMessages: nil,
-		Errors: map[string]string{
-			"workpackagetype": "WorkPackage type is required",
-			"title":           "title is required",
-		},
-	}
+	multiErr := testMultipleErrors()
 
 	noErr := &Error{
-		HTTPError:     errors.New("Original http error"),
-		ErrorMessages: nil,
-		Errors:        nil,
+		HTTPError: errors.New("Original http error"),
 	}
 
 	err := msgErr.Error()
@@ -169,8 +169,8 @@ func TestError_ShortMessage(t *testing.T) {
 		t.Errorf("Expected short message. Got %s", err)
 	}
 
-	err = mapErr.Error()
-	if !(strings.Contains(err, "WorkPackage type is required") || strings.Contains(err, "title is required")) {
+	err = multiErr.Error()
+	if !(strings.Contains(err, "Subject can't be blank.") && strings.Contains(err, "ID was attempted to be written")) {
 		t.Errorf("Expected short message. Got %s", err)
 	}
 
@@ -181,25 +181,83 @@ func TestError_ShortMessage(t *testing.T) {
 }
 
 func TestError_LongMessage(t *testing.T) {
-	longError := &Error{
-		HTTPError:     errors.New("Original http error"),
-		ErrorMessages: []string{"WorkPackage does not exist."},
-		Errors: map[string]string{
-			"workpackagetype": "WorkPackage type is required",
-			"title":           "title is required",
-		},
-	}
+	longError := testMultipleErrors()
 
 	msg := longError.LongError()
 	if !strings.Contains(msg, "Original http error") {
 		t.Errorf("Expected the error message: Got\n%s\n", msg)
 	}
 
-	if !strings.Contains(msg, "WorkPackage does not exist") {
-		t.Errorf("Expected the error message: Got\n%s\n", msg)
+	if !strings.Contains(msg, "urn:openproject-org:api:v3:errors:MultipleErrors") {
+		t.Errorf("Expected the error identifier: Got\n%s\n", msg)
+	}
+
+	if !strings.Contains(msg, "subject - Subject can't be blank.") {
+		t.Errorf("Expected the embedded errors: Got\n%s\n", msg)
+	}
+}
+
+func TestError_Is(t *testing.T) {
+	multiErr := testMultipleErrors()
+	if !errors.Is(multiErr, ErrPropertyIsReadOnly) {
+		t.Error("Expected multiple errors to match ErrPropertyIsReadOnly")
+	}
+	if !errors.Is(multiErr, ErrPropertyConstraintViolation) {
+		t.Error("Expected multiple errors to match ErrPropertyConstraintViolation")
+	}
+	if errors.Is(multiErr, ErrNotFound) {
+		t.Error("Expected multiple errors not to match ErrNotFound")
+	}
+
+	statusErr := &Error{StatusCode: http.StatusNotFound}
+	if !errors.Is(statusErr, ErrNotFound) {
+		t.Error("Expected status code 404 to match ErrNotFound")
+	}
+
+	conflictErr := &ConflictError{LockVersion: 1, HTTPError: errors.New("Original http error")}
+	if !errors.Is(conflictErr, ErrConflict) {
+		t.Error("Expected ConflictError to match ErrConflict")
+	}
+}
+
+func TestError_ValidationErrors(t *testing.T) {
+	validation := testMultipleErrors().ValidationErrors()
+	if len(validation) != 2 {
+		t.Fatalf("Expected 2 attributes. Got %v", validation)
+	}
+	if validation["subject"][0] != "Subject can't be blank." {
+		t.Errorf("Unexpected subject error %v", validation["subject"])
+	}
+}
+
+func TestCheckResponse_OpenProjectError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/api/v3/projects/demo-project/work_packages", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:MultipleErrors","message":"Multiple field constraints have been violated.","_embedded":{"errors":[{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:PropertyConstraintViolation","message":"Subject can't be blank.","_embedded":{"details":{"attribute":"subject"}}}]}}`)
+	})
+
+	_, resp, err := testClient.WorkPackage.Create(&WorkPackage{}, "demo-project")
+	var opErr *Error
+	if !errors.As(err, &opErr) {
+		t.Fatalf("Expected OpenProject Error. Got %T: %v", err, err)
+	}
+	if opErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code 422. Got %d", opErr.StatusCode)
+	}
+	if !errors.Is(err, ErrPropertyConstraintViolation) {
+		t.Errorf("Expected ErrPropertyConstraintViolation. Got %v", err)
+	}
+	if attribute := opErr.Embedded.Errors[0].Attribute(); attribute != "subject" {
+		t.Errorf("Expected attribute subject. Got %s", attribute)
 	}
 
-	if !strings.Contains(msg, "title - title is required") {
-		t.Errorf("Expected the error map: Got\n%s\n", msg)
+	// The body must still be readable by the caller
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "MultipleErrors") {
+		t.Errorf("Expected the response body to be readable. Got %s", string(body))
 	}
 }
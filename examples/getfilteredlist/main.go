@@ -17,15 +17,7 @@ func main() {
 		return
 	}
 
-	opt := &openproj.FilterOptions{
-		Fields: []openproj.OptionsFields{
-			{
-				Field:    "status",
-				Operator: openproj.Equal,
-				Value:    "21",
-			},
-		},
-	}
+	opt := openproj.NewFilterOptions(openproj.FilterStatus(21))
 
 	wpResponse, resp, err := client.WorkPackage.GetList(opt, 0, 10)
 	if err != nil {
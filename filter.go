@@ -0,0 +1,283 @@
+package openproject
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Constants to represent OpenProject standard GET parameters
+const paramFilters = "filters"
+
+// Me is the filter value standing for the current user, e.g. FilterAssignedTo(Me)
+const Me = "me"
+
+// ErrUnsupportedOrGroup is returned when an OR group combines different fields or operators,
+// which the OpenProject API can not express.
+var ErrUnsupportedOrGroup = errors.New("openproject: OR groups are only supported between filters on the same field and operator")
+
+// relativeDateOperators are the operators taking a number of days as value
+var relativeDateOperators = map[SearchOperator]bool{
+	DaysAgo:         true,
+	MoreThanDaysAgo: true,
+	LessThanDaysAgo: true,
+	InDays:          true,
+	InMoreThanDays:  true,
+	InLessThanDays:  true,
+}
+
+// FilterOptions allows you to specify search parameters for the get-workpackage action
+// When used they will be converted to GET parameters within the URL
+// Every filter must match ("AND" combination). "OR" combinations are only supported by the API between values of
+// the same field, see Or. Generic "OR" combinations feature is under development,
+// tracked by this ticket https://community.openproject.org/projects/openproject/work_packages/26837/activity
+// More information about filters https://docs.openproject.org/api/filters/
+// Usage case:
+//
+//	opt := NewFilterOptions(FilterStatus(Open), FilterAssignedTo(Me), FilterUpdatedSince(time.Now().AddDate(0, 0, -7)))
+type FilterOptions struct {
+	// Fields are plain text filters, each with a single value.
+	//
+	// Deprecated: use Filters, which take several typed values, e.g. FilterStatus(1, 2).
+	Fields []OptionsFields
+	// Filters are typed filters, see NewFilter
+	Filters []Filter
+	// ListOptions sort, group, sum or select the elements
+	ListOptions
+
+	err error
+}
+
+// OptionsFields array wraps field, Operator, Value within FilterOptions
+type OptionsFields struct {
+	Field    string
+	Operator SearchOperator
+	Value    string
+}
+
+// Filter is a typed filter condition on a single field
+type Filter struct {
+	// Field is the name of the filtered property, e.g. status or assignee
+	Field string
+	// Operator compares the property with the values
+	Operator SearchOperator
+	// Values can be strings, integers, booleans, time.Time (datetimes), Date (days),
+	// time.Duration or Duration (days for relative date operators, hours otherwise) or Href (resource links).
+	Values []interface{}
+}
+
+// Href is a resource link used as filter value, e.g. /api/v3/users/5. It is sent as the ID of the resource.
+type Href string
+
+// NewFilterOptions returns FilterOptions matching every given filter
+func NewFilterOptions(filters ...Filter) *FilterOptions {
+	return &FilterOptions{Filters: filters}
+}
+
+// Add adds filters that must all match
+func (fops *FilterOptions) Add(filters ...Filter) *FilterOptions {
+	fops.Filters = append(fops.Filters, filters...)
+	return fops
+}
+
+// Or adds a group of filters of which any must match.
+// The API only supports it between filters on the same field and operator, whose values are merged:
+// Or(FilterStatus(1), FilterStatus(2)) is FilterStatus(1, 2). Any other group fails the request with ErrUnsupportedOrGroup.
+func (fops *FilterOptions) Or(filters ...Filter) *FilterOptions {
+	if len(filters) == 0 {
+		return fops
+	}
+	merged := Filter{Field: filters[0].Field, Operator: filters[0].Operator}
+	for _, filter := range filters {
+		if filter.Field != merged.Field || filter.Operator != merged.Operator {
+			if fops.err == nil {
+				fops.err = errors.Wrapf(ErrUnsupportedOrGroup, "%s %s OR %s %s",
+					merged.Field, merged.Operator, filter.Field, filter.Operator)
+			}
+			return fops
+		}
+		merged.Values = append(merged.Values, filter.Values...)
+	}
+	fops.Filters = append(fops.Filters, merged)
+	return fops
+}
+
+// NewFilter returns a filter on field
+func NewFilter(field string, operator SearchOperator, values ...interface{}) Filter {
+	return Filter{Field: field, Operator: operator, Values: values}
+}
+
+// fieldFilter returns a filter on field. The operator may be given as first argument, it defaults to Equal.
+func fieldFilter(field string, args []interface{}) Filter {
+	if len(args) > 0 {
+		if operator, ok := args[0].(SearchOperator); ok {
+			return NewFilter(field, operator, args[1:]...)
+		}
+	}
+	return NewFilter(field, Equal, args...)
+}
+
+// FilterID filters by ID, e.g. FilterID(1, 2, 3)
+func FilterID(args ...interface{}) Filter {
+	return fieldFilter("id", args)
+}
+
+// FilterStatus filters by status, e.g. FilterStatus(Open), FilterStatus(1, 2) or FilterStatus(NotEqual, 5)
+func FilterStatus(args ...interface{}) Filter {
+	return fieldFilter("status", args)
+}
+
+// FilterAssignedTo filters by assignee, e.g. FilterAssignedTo(Me), FilterAssignedTo(5) or FilterAssignedTo(None)
+func FilterAssignedTo(args ...interface{}) Filter {
+	return fieldFilter("assignee", args)
+}
+
+// FilterAuthor filters by author, e.g. FilterAuthor(Me)
+func FilterAuthor(args ...interface{}) Filter {
+	return fieldFilter("author", args)
+}
+
+// FilterResponsible filters by accountable user, e.g. FilterResponsible(Me)
+func FilterResponsible(args ...interface{}) Filter {
+	return fieldFilter("responsible", args)
+}
+
+// FilterProject filters by project, e.g. FilterProject(3)
+func FilterProject(args ...interface{}) Filter {
+	return fieldFilter("project", args)
+}
+
+// FilterType filters by type, e.g. FilterType(1, 2)
+func FilterType(args ...interface{}) Filter {
+	return fieldFilter("type", args)
+}
+
+// FilterPriority filters by priority, e.g. FilterPriority(8)
+func FilterPriority(args ...interface{}) Filter {
+	return fieldFilter("priority", args)
+}
+
+// FilterVersion filters by version, e.g. FilterVersion(4) or FilterVersion(None)
+func FilterVersion(args ...interface{}) Filter {
+	return fieldFilter("version", args)
+}
+
+// FilterSubjectContains filters work-packages whose subject contains text
+func FilterSubjectContains(text string) Filter {
+	return NewFilter("subject", Like, text)
+}
+
+// FilterUpdatedSince filters resources updated after t
+func FilterUpdatedSince(t time.Time) Filter {
+	return NewFilter("updatedAt", BetweenDates, t, "")
+}
+
+// FilterCreatedSince filters resources created after t
+func FilterCreatedSince(t time.Time) Filter {
+	return NewFilter("createdAt", BetweenDates, t, "")
+}
+
+// FilterDueBefore filters work-packages due on or before day
+func FilterDueBefore(day Date) Filter {
+	return NewFilter("dueDate", BetweenDates, "", day)
+}
+
+// filterJSON is the JSON representation of a filter
+type filterJSON struct {
+	Operator SearchOperator `json:"operator"`
+	Values   []string       `json:"values"`
+}
+
+// prepareFilters convert FilterOptions to single URL-Encoded string to be inserted into GET request
+// as parameter.
+func (fops *FilterOptions) prepareFilters(oldValues url.Values) (url.Values, error) {
+	values := oldValues
+	if oldValues == nil {
+		values = make(url.Values)
+	}
+	if fops.err != nil {
+		return nil, fops.err
+	}
+	if err := fops.ListOptions.prepare(values); err != nil {
+		return nil, err
+	}
+	filters := make([]map[string]filterJSON, 0, len(fops.Fields)+len(fops.Filters))
+	for _, field := range fops.Fields {
+		filters = append(filters, map[string]filterJSON{
+			field.Field: {Operator: field.Operator, Values: []string{field.Value}},
+		})
+	}
+	for _, filter := range fops.Filters {
+		filterValues := make([]string, 0, len(filter.Values))
+		for _, value := range filter.Values {
+			formatted, err := formatFilterValue(filter.Operator, value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "filter %s", filter.Field)
+			}
+			filterValues = append(filterValues, formatted)
+		}
+		filters = append(filters, map[string]filterJSON{
+			filter.Field: {Operator: filter.Operator, Values: filterValues},
+		})
+	}
+
+	// Operators like <>d must not be HTML escaped
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(filters); err != nil {
+		return nil, err
+	}
+	values.Add(paramFilters, strings.TrimSuffix(buf.String(), "\n"))
+
+	return values, nil
+}
+
+// formatFilterValue converts a typed filter value to the string the API expects
+func formatFilterValue(operator SearchOperator, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case Href:
+		href := strings.TrimRight(string(v), "/")
+		return href[strings.LastIndex(href, "/")+1:], nil
+	case bool:
+		// Boolean filters expect t or f
+		if v {
+			return "t", nil
+		}
+		return "f", nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case time.Time:
+		return v.UTC().Format(time.RFC3339), nil
+	case Time:
+		return formatFilterValue(operator, v.Time())
+	case Date:
+		return v.String(), nil
+	case time.Duration:
+		if relativeDateOperators[operator] {
+			return strconv.Itoa(int(v / (24 * time.Hour))), nil
+		}
+		return strconv.FormatFloat(v.Hours(), 'f', -1, 64), nil
+	case Duration:
+		return formatFilterValue(operator, v.Duration())
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	return "", fmt.Errorf("unsupported filter value type %T", value)
+}
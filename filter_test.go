@@ -0,0 +1,109 @@
+package openproject
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFilterOptions_PrepareFilters(t *testing.T) {
+	since := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	due := Date(time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name    string
+		options *FilterOptions
+		want    string
+	}{
+		{
+			name:    "legacy fields",
+			options: &FilterOptions{Fields: []OptionsFields{{Field: "subject", Operator: Like, Value: "Login, logout"}}},
+			want:    `[{"subject":{"operator":"~","values":["Login, logout"]}}]`,
+		},
+		{
+			name:    "operators without values",
+			options: NewFilterOptions(FilterStatus(Open), FilterVersion(None)),
+			want:    `[{"status":{"operator":"o","values":[]}},{"version":{"operator":"!*","values":[]}}]`,
+		},
+		{
+			name:    "escaped text",
+			options: NewFilterOptions(FilterSubjectContains(`Say "hi", then <leave>`)),
+			want:    `[{"subject":{"operator":"~","values":["Say \"hi\", then <leave>"]}}]`,
+		},
+		{
+			name:    "typed values",
+			options: NewFilterOptions(FilterAssignedTo(Me), FilterType(1, int64(2)), NewFilter("isMilestone", Equal, false)),
+			want:    `[{"assignee":{"operator":"=","values":["me"]}},{"type":{"operator":"=","values":["1","2"]}},{"isMilestone":{"operator":"=","values":["f"]}}]`,
+		},
+		{
+			name:    "dates",
+			options: NewFilterOptions(FilterUpdatedSince(since), FilterDueBefore(due)),
+			want:    `[{"updatedAt":{"operator":"<>d","values":["2023-01-02T15:04:05Z",""]}},{"dueDate":{"operator":"<>d","values":["","2023-02-01"]}}]`,
+		},
+		{
+			name: "durations and hrefs",
+			options: NewFilterOptions(
+				NewFilter("createdAt", LessThanDaysAgo, 7*24*time.Hour),
+				NewFilter("estimatedTime", GreaterOrEqual, 90*time.Minute),
+				FilterAuthor(Href("/api/v3/users/5")),
+			),
+			want: `[{"createdAt":{"operator":"<t-","values":["7"]}},{"estimatedTime":{"operator":">=","values":["1.5"]}},{"author":{"operator":"=","values":["5"]}}]`,
+		},
+		{
+			name:    "or group",
+			options: NewFilterOptions(FilterStatus(Open)).Or(FilterAssignedTo(Me), FilterAssignedTo(5)),
+			want:    `[{"status":{"operator":"o","values":[]}},{"assignee":{"operator":"=","values":["me","5"]}}]`,
+		},
+	}
+	for _, test := range tests {
+		values, err := test.options.prepareFilters(nil)
+		if err != nil {
+			t.Errorf("%s: error given: %s", test.name, err)
+			continue
+		}
+		if got := values.Get("filters"); got != test.want {
+			t.Errorf("%s: expected\n%s\nGot\n%s", test.name, test.want, got)
+		}
+	}
+}
+
+func TestFilterOptions_Errors(t *testing.T) {
+	_, err := NewFilterOptions().Or(FilterStatus(Open), FilterAssignedTo(Me)).prepareFilters(nil)
+	if !errors.Is(err, ErrUnsupportedOrGroup) {
+		t.Errorf("Expected ErrUnsupportedOrGroup. Got %v", err)
+	}
+
+	_, err = NewFilterOptions(FilterID(struct{}{})).prepareFilters(nil)
+	if err == nil {
+		t.Error("Expected an error for unsupported value types")
+	}
+}
+
+func TestProjectService_GetList_Filtered(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/api/v3/projects", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		want := `[{"active":{"operator":"=","values":["t"]}}]`
+		if got := r.URL.Query().Get("filters"); got != want {
+			t.Errorf("Expected filters %s. Got %s", want, got)
+		}
+		fmt.Fprint(w, `{"_type":"Collection","total":0,"count":0,"pageSize":10,"offset":1,"_embedded":{"elements":[]}}`)
+	})
+
+	if _, _, err := testClient.Project.GetList(NewFilterOptions(NewFilter("active", Equal, true)), 1, 10); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestWorkPackageService_GetList_FilterError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	opt := NewFilterOptions().Or(FilterStatus(1), FilterType(2))
+	if _, _, err := testClient.WorkPackage.GetList(opt, 1, 10); !errors.Is(err, ErrUnsupportedOrGroup) {
+		t.Errorf("Expected ErrUnsupportedOrGroup. Got %v", err)
+	}
+}
@@ -3,10 +3,10 @@ module github.com/manuelbcd/go-openproject
 go 1.19
 
 require (
-	github.com/emirpasic/gods v1.18.1
 	github.com/pkg/errors v0.9.1
 	github.com/trivago/tgo v1.0.7
 	golang.org/x/crypto v0.0.0-20220722155217-630584e8d5aa
+	golang.org/x/term v0.0.0-20210927222741-03fcf44c2211
 )
 
 require (
@@ -14,7 +14,6 @@ require (
 	golang.org/x/exp/typeparams v0.0.0-20221114191408-850992195362 // indirect
 	golang.org/x/mod v0.7.0 // indirect
 	golang.org/x/sys v0.2.0 // indirect
-	golang.org/x/term v0.0.0-20210927222741-03fcf44c2211 // indirect
 	golang.org/x/tools v0.3.0 // indirect
 	honnef.co/go/tools v0.3.3 // indirect
 )
@@ -0,0 +1,275 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// groupMembersAttempts is how many times AddMembers and RemoveMembers patch the members of a group
+// before giving up because of concurrent modifications
+const groupMembersAttempts = 3
+
+// ErrGroupMembersConflict is returned when the members of a group keep being modified concurrently
+var ErrGroupMembersConflict = errors.New("openproject: group members modified concurrently")
+
+// GroupService handles groups of users for the OpenProject instance / API.
+type GroupService struct {
+	client *Client
+}
+
+// Group is the object representing OpenProject groups
+type Group struct {
+	Type      string         `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID        int            `json:"id,omitempty" structs:"id,omitempty"`
+	Name      string         `json:"name,omitempty" structs:"name,omitempty"`
+	CreatedAt *Time          `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	UpdatedAt *Time          `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+	Embedded  *GroupEmbedded `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Links     GroupLinks     `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// GroupEmbedded holds the users of a group as returned by the API
+type GroupEmbedded struct {
+	Members []User `json:"members,omitempty" structs:"members,omitempty"`
+}
+
+// GroupLinks are Group Links
+type GroupLinks struct {
+	Self              *OPGenericLink  `json:"self,omitempty" structs:"self,omitempty"`
+	Delete            *OPGenericLink  `json:"delete,omitempty" structs:"delete,omitempty"`
+	UpdateImmediately *OPGenericLink  `json:"updateImmediately,omitempty" structs:"updateImmediately,omitempty"`
+	Memberships       *OPGenericLink  `json:"memberships,omitempty" structs:"memberships,omitempty"`
+	Members           []OPGenericLink `json:"members,omitempty" structs:"members,omitempty"`
+}
+
+// NewGroup returns a group named name with the given users, ready to be created
+func NewGroup(name string, userIDs ...string) *Group {
+	return &Group{Name: name, Links: GroupLinks{Members: userLinks(userIDs)}}
+}
+
+// Members returns the users of the group embedded by the API
+func (g *Group) Members() []User {
+	if g.Embedded == nil {
+		return nil
+	}
+	return g.Embedded.Members
+}
+
+// MemberIDs returns the IDs of the users of the group
+func (g *Group) MemberIDs() []string {
+	ids := make([]string, 0, len(g.Links.Members))
+	for i := range g.Links.Members {
+		ids = append(ids, g.Links.Members[i].LinkID())
+	}
+	return ids
+}
+
+// userLinks returns the links to the users
+func userLinks(userIDs []string) []OPGenericLink {
+	links := make([]OPGenericLink, 0, len(userIDs))
+	for _, userID := range userIDs {
+		links = append(links, *resourceLink("users", userID))
+	}
+	return links
+}
+
+// GroupPatch holds the changes of a group update. Nil fields are left unchanged, members are changed with SetMembers,
+// AddMembers and RemoveMembers.
+type GroupPatch struct {
+	Name *string `json:"name,omitempty" structs:"name,omitempty"`
+}
+
+// groupMembersPatch is the body of a request replacing the members of a group.
+// Members are always sent, an empty list removes every member.
+type groupMembersPatch struct {
+	Links struct {
+		Members []OPGenericLink `json:"members"`
+	} `json:"_links"`
+}
+
+// SearchResultGroup represent a list of groups
+type SearchResultGroup struct {
+	Embedded groupElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	PaginationParam
+}
+
+func (s *SearchResultGroup) TotalPage() int {
+	return int(math.Ceil(float64(s.Total) / float64(s.PageSize)))
+}
+
+func (s *SearchResultGroup) ConcatEmbed(groups interface{}) {
+	s.Embedded.Elements = append(s.Embedded.Elements, groups.(*SearchResultGroup).Embedded.Elements...)
+}
+
+// Elements returns the groups of the page
+func (s *SearchResultGroup) Elements() []Group {
+	return s.Embedded.Elements
+}
+
+// groupElements array wraps elements within SearchResultGroup
+type groupElements struct {
+	Elements []Group `json:"elements,omitempty" structs:"elements,omitempty"`
+}
+
+// GetWithContext gets a group from OpenProject using its ID
+func (s *GroupService) GetWithContext(ctx context.Context, groupID string) (*Group, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/groups/%s", groupID)
+	obj, resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Group), resp, err
+}
+
+// Get wraps GetWithContext using the background context.
+func (s *GroupService) Get(groupID string) (*Group, *Response, error) {
+	return s.GetWithContext(context.Background(), groupID)
+}
+
+// GetListWithContext retrieves the groups of the instance along with their users
+func (s *GroupService) GetListWithContext(ctx context.Context, options *FilterOptions, offset int, pageSize int) (*SearchResultGroup, *Response, error) {
+	apiEndpoint := "api/v3/groups"
+	obj, resp, err := GetListWithContext(ctx, s, apiEndpoint, options, offset, pageSize)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*SearchResultGroup), resp, err
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *GroupService) GetList(options *FilterOptions, offset int, pageSize int) (*SearchResultGroup, *Response, error) {
+	return s.GetListWithContext(context.Background(), options, offset, pageSize)
+}
+
+// CreateWithContext creates a group, see NewGroup
+func (s *GroupService) CreateWithContext(ctx context.Context, group *Group) (*Group, *Response, error) {
+	apiEndpoint := "api/v3/groups"
+	obj, resp, err := CreateWithContext(ctx, group, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Group), resp, err
+}
+
+// Create wraps CreateWithContext using the background context.
+func (s *GroupService) Create(group *Group) (*Group, *Response, error) {
+	return s.CreateWithContext(context.Background(), group)
+}
+
+// UpdateWithContext updates a group, e.g. renames it with &GroupPatch{Name: Ptr("Designers")}. Its members are kept.
+func (s *GroupService) UpdateWithContext(ctx context.Context, groupID string, patch *GroupPatch) (*Group, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/groups/%s", groupID)
+	obj, resp, err := UpdateWithContext(ctx, patch, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Group), resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *GroupService) Update(groupID string, patch *GroupPatch) (*Group, *Response, error) {
+	return s.UpdateWithContext(context.Background(), groupID, patch)
+}
+
+// DeleteWithContext deletes a group. OpenProject removes it in the background and answers 202 Accepted.
+func (s *GroupService) DeleteWithContext(ctx context.Context, groupID string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/groups/%s", groupID)
+	return DeleteWithContext(ctx, s, apiEndpoint)
+}
+
+// Delete wraps DeleteWithContext using the background context.
+func (s *GroupService) Delete(groupID string) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), groupID)
+}
+
+// SetMembersWithContext replaces the users of a group. No userIDs removes every member.
+func (s *GroupService) SetMembersWithContext(ctx context.Context, groupID string, userIDs ...string) (*Group, *Response, error) {
+	patch := new(groupMembersPatch)
+	patch.Links.Members = userLinks(userIDs)
+	apiEndpoint := fmt.Sprintf("api/v3/groups/%s", groupID)
+	obj, resp, err := UpdateWithContext(ctx, patch, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Group), resp, err
+}
+
+// SetMembers wraps SetMembersWithContext using the background context.
+func (s *GroupService) SetMembers(groupID string, userIDs ...string) (*Group, *Response, error) {
+	return s.SetMembersWithContext(context.Background(), groupID, userIDs...)
+}
+
+// AddMembersWithContext adds users to a group. The group is fetched first, and only patched if some users are not members yet.
+//
+// The API can only replace the whole member list and groups have no lock version, so a member added or removed by
+// someone else between the fetch and the patch is lost. The group is fetched again after patching, and patched again
+// if the users are missing, up to 3 times before failing with ErrGroupMembersConflict.
+func (s *GroupService) AddMembersWithContext(ctx context.Context, groupID string, userIDs ...string) (*Group, *Response, error) {
+	return s.changeMembers(ctx, groupID, func(members []string) ([]string, bool) {
+		isMember := make(map[string]bool, len(members))
+		for _, id := range members {
+			isMember[id] = true
+		}
+		changed := false
+		for _, id := range userIDs {
+			if !isMember[id] {
+				isMember[id] = true
+				members = append(members, id)
+				changed = true
+			}
+		}
+		return members, changed
+	})
+}
+
+// AddMembers wraps AddMembersWithContext using the background context.
+func (s *GroupService) AddMembers(groupID string, userIDs ...string) (*Group, *Response, error) {
+	return s.AddMembersWithContext(context.Background(), groupID, userIDs...)
+}
+
+// RemoveMembersWithContext removes users from a group. The group is fetched first, and only patched if some users are members.
+// It is subject to the same race as AddMembersWithContext.
+func (s *GroupService) RemoveMembersWithContext(ctx context.Context, groupID string, userIDs ...string) (*Group, *Response, error) {
+	removed := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		removed[id] = true
+	}
+	return s.changeMembers(ctx, groupID, func(current []string) ([]string, bool) {
+		members := make([]string, 0, len(current))
+		for _, id := range current {
+			if !removed[id] {
+				members = append(members, id)
+			}
+		}
+		return members, len(members) != len(current)
+	})
+}
+
+// RemoveMembers wraps RemoveMembersWithContext using the background context.
+func (s *GroupService) RemoveMembers(groupID string, userIDs ...string) (*Group, *Response, error) {
+	return s.RemoveMembersWithContext(context.Background(), groupID, userIDs...)
+}
+
+// changeMembers patches the members of a group as returned by change, until a fresh copy of the group needs no change
+func (s *GroupService) changeMembers(ctx context.Context, groupID string,
+	change func(members []string) ([]string, bool)) (*Group, *Response, error) {
+	for attempt := 0; ; attempt++ {
+		group, resp, err := s.GetWithContext(ctx, groupID)
+		if err != nil {
+			return nil, resp, err
+		}
+		members, changed := change(group.MemberIDs())
+		if !changed {
+			return group, resp, nil
+		}
+		if attempt == groupMembersAttempts {
+			return nil, resp, errors.Wrapf(ErrGroupMembersConflict, "group %s", groupID)
+		}
+		if _, resp, err := s.SetMembersWithContext(ctx, groupID, members...); err != nil {
+			return nil, resp, err
+		}
+	}
+}
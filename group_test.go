@@ -0,0 +1,194 @@
+package openproject
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestGroupService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-group.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/groups/7", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/api/v3/groups/7")
+		fmt.Fprint(w, string(raw))
+	})
+
+	group, _, err := testClient.Group.Get("7")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if group.Name != "Developers" || len(group.Members()) != 2 || group.Members()[1].Login != "jsmith" {
+		t.Errorf("Unexpected group %+v", group)
+	}
+	if !reflect.DeepEqual(group.MemberIDs(), []string{"5", "6"}) {
+		t.Errorf("Unexpected member IDs %v", group.MemberIDs())
+	}
+}
+
+func TestGroupService_GetList(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-group.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"_type":"Collection","total":1,"count":1,"pageSize":20,"offset":1,"_embedded":{"elements":[%s]}}`, raw)
+	})
+
+	groups, resp, err := testClient.Group.GetList(nil, 1, 20)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if resp.Total != 1 || len(groups.Elements()) != 1 || len(groups.Elements()[0].Members()) != 2 {
+		t.Errorf("Expected 1 group with its 2 users. Got %+v", groups.Elements())
+	}
+}
+
+func TestGroupService_CreateDelete(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		body, _ := io.ReadAll(r.Body)
+		want := `{"name":"Designers","_links":{"members":[{"href":"/api/v3/users/5"}]}}`
+		if string(body) != want+"\n" {
+			t.Errorf("Expected body %s. Got %s", want, body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"_type":"Group","id":8,"name":"Designers"}`)
+	})
+	testMux.HandleFunc("/api/v3/groups/8", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	group, _, err := testClient.Group.Create(NewGroup("Designers", "5"))
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if group.ID != 8 {
+		t.Errorf("Expected group 8. Got %d", group.ID)
+	}
+	if resp, err := testClient.Group.Delete("8"); err != nil || resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Expected group deletion to be accepted. Got %v", err)
+	}
+}
+
+// handleGroupMembers serves group 7 with its members as patched, except for the first lostPatches patches which are
+// overwritten as if someone else had replaced the members concurrently. It returns the members links of the patches.
+func handleGroupMembers(t *testing.T, lostPatches int) *[]string {
+	members := `[{"href":"/api/v3/users/5"},{"href":"/api/v3/users/6"}]`
+	var patches []string
+	testMux.HandleFunc("/api/v3/groups/7", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+		case "PATCH":
+			var body struct {
+				Links map[string]json.RawMessage `json:"_links"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			patches = append(patches, `{"members":`+string(body.Links["members"])+`}`)
+			if len(patches) > lostPatches {
+				members = string(body.Links["members"])
+			}
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+		fmt.Fprintf(w, `{"_type":"Group","id":7,"name":"Developers","_links":{"members":%s}}`, members)
+	})
+	return &patches
+}
+
+func TestGroupService_AddRemoveMembers(t *testing.T) {
+	setup()
+	defer teardown()
+	patches := handleGroupMembers(t, 0)
+
+	if _, _, err := testClient.Group.AddMembers("7", "6", "9"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := testClient.Group.AddMembers("7", "5"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := testClient.Group.RemoveMembers("7", "5", "9"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := testClient.Group.RemoveMembers("7", "9"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := testClient.Group.SetMembers("7"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	want := []string{
+		`{"members":[{"href":"/api/v3/users/5"},{"href":"/api/v3/users/6"},{"href":"/api/v3/users/9"}]}`,
+		`{"members":[{"href":"/api/v3/users/6"}]}`,
+		`{"members":[]}`,
+	}
+	if !reflect.DeepEqual(*patches, want) {
+		t.Errorf("Expected patches %v. Got %v", want, *patches)
+	}
+}
+
+func TestGroupService_AddMembers_ConcurrentUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+	patches := handleGroupMembers(t, 1)
+
+	group, _, err := testClient.Group.AddMembers("7", "9")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(*patches) != 2 {
+		t.Errorf("Expected the lost patch to be sent again. Got %v", *patches)
+	}
+	if ids := group.MemberIDs(); !reflect.DeepEqual(ids, []string{"5", "6", "9"}) {
+		t.Errorf("Expected members 5, 6 and 9. Got %v", ids)
+	}
+
+	teardown()
+	setup()
+	patches = handleGroupMembers(t, 100)
+	if _, _, err := testClient.Group.AddMembers("7", "9"); !errors.Is(err, ErrGroupMembersConflict) {
+		t.Errorf("Expected ErrGroupMembersConflict. Got %v", err)
+	}
+	if len(*patches) != groupMembersAttempts {
+		t.Errorf("Expected %d patches. Got %d", groupMembersAttempts, len(*patches))
+	}
+}
+
+func TestGroupService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/groups/7", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		body, _ := io.ReadAll(r.Body)
+		if want := `{"name":"Designers"}` + "\n"; string(body) != want {
+			t.Errorf("Expected only the name to be sent. Got %s", body)
+		}
+		fmt.Fprint(w, `{"_type":"Group","id":7,"name":"Designers"}`)
+	})
+
+	group, _, err := testClient.Group.Update("7", &GroupPatch{Name: Ptr("Designers")})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if group.Name != "Designers" {
+		t.Errorf("Expected group Designers. Got %s", group.Name)
+	}
+}
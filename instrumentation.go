@@ -0,0 +1,233 @@
+package openproject
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// apiPathPrefix is the path prefix of every API v3 endpoint
+const apiPathPrefix = "api/v3/"
+
+// endpointKeywords are path segments standing where an ID is expected which are not IDs, e.g. api/v3/users/me
+var endpointKeywords = map[string]bool{
+	"me":                      true,
+	"default":                 true,
+	"form":                    true,
+	"schema":                  true,
+	"schemas":                 true,
+	"available_projects":      true,
+	"filter_instance_schemas": true,
+	"activities":              true,
+}
+
+// endpointServices maps API collections to the name of the service dealing with them
+var endpointServices = map[string]string{
+	"work_packages": "WorkPackage",
+	"projects":      "Project",
+	"users":         "User",
+	"statuses":      "Status",
+	"wiki_pages":    "WikiPage",
+	"attachments":   "Attachment",
+	"categories":    "Category",
+	"queries":       "Query",
+	"activities":    "Activities",
+	"time_entries":  "TimeEntry",
+	"relations":     "Relation",
+	"versions":      "Version",
+	"types":         "Type",
+	"priorities":    "Priority",
+	"memberships":   "Membership",
+	"roles":         "Role",
+	"principals":    "Principal",
+	"groups":        "Group",
+}
+
+// numericSegment matches path segments made of digits only
+var numericSegment = regexp.MustCompile(`^[0-9]+$`)
+
+// Instrumentation receives tracing and metrics events of every request sent by the Client,
+// see the otelopenproject package for an OpenTelemetry implementation.
+// Implementations must be safe for concurrent use.
+type Instrumentation interface {
+	// StartRequest is called before the request is sent. The returned context is the one of the request
+	// and the one given to EndRequest, so it can carry a span.
+	StartRequest(ctx context.Context, info RequestInfo) context.Context
+
+	// EndRequest is called once the response is decoded, or when the request failed.
+	EndRequest(ctx context.Context, info RequestInfo, result RequestResult)
+}
+
+// RequestInfo describes a request sent by the Client
+type RequestInfo struct {
+	// Service is the name of the service of the requested resource, e.g. WorkPackage or Project
+	Service string
+	// Method is the HTTP method
+	Method string
+	// Endpoint is the path of the request with IDs replaced by {id}, e.g. api/v3/work_packages/{id}.
+	// Its cardinality is low enough to be used as a metric label.
+	Endpoint string
+	// URL is the full URL of the request
+	URL string
+}
+
+// RequestResult describes the outcome of a request sent by the Client
+type RequestResult struct {
+	// StatusCode is the HTTP status code of the last attempt, 0 if no response was received
+	StatusCode int
+	// Retries is the number of attempts made after the first one
+	Retries int
+	// RequestBytes is the size of the request body, -1 if unknown
+	RequestBytes int64
+	// ResponseBytes is the size of the response body read by the Client.
+	// When the body is left to the caller (e.g. downloads) it is the announced Content-Length, -1 if unknown.
+	ResponseBytes int64
+	// Duration is the time spent from the first attempt until the response was decoded
+	Duration time.Duration
+	// Err is the error returned to the caller, if any
+	Err error
+}
+
+// WithInstrumentation sends tracing and metrics events of every request to instrumentation
+func WithInstrumentation(instrumentation Instrumentation) ClientOption {
+	return func(c *Client) {
+		c.instrumentation = instrumentation
+	}
+}
+
+// requestSpanKey is the context key of the requestSpan of a request
+type requestSpanKey struct{}
+
+// requestSpan collects the metrics of a request while it goes through the client
+type requestSpan struct {
+	instrumentation Instrumentation
+	ctx             context.Context
+	info            RequestInfo
+	start           time.Time
+	requestBytes    int64
+	retries         int32
+	responseBytes   int64
+}
+
+// startRequest notifies the instrumentation of the client that req is about to be sent.
+// It returns the request to send, carrying the span, and the span to end once done, nil if there is no instrumentation.
+func (c *Client) startRequest(req *http.Request) (*http.Request, *requestSpan) {
+	if c.instrumentation == nil {
+		return req, nil
+	}
+	service, endpoint := c.endpointTemplate(req.URL.Path)
+	span := &requestSpan{
+		instrumentation: c.instrumentation,
+		info: RequestInfo{
+			Service:  service,
+			Method:   req.Method,
+			Endpoint: endpoint,
+			URL:      req.URL.Redacted(),
+		},
+		start:        time.Now(),
+		requestBytes: req.ContentLength,
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.ContentLength == 0 {
+		span.requestBytes = -1
+	}
+	ctx := span.instrumentation.StartRequest(req.Context(), span.info)
+	span.ctx = ctx
+	return req.WithContext(context.WithValue(ctx, requestSpanKey{}, span)), span
+}
+
+// requestSpanFromContext returns the span of the request, nil if the request is not instrumented
+func requestSpanFromContext(ctx context.Context) *requestSpan {
+	span, _ := ctx.Value(requestSpanKey{}).(*requestSpan)
+	return span
+}
+
+// retry records an attempt made after the first one
+func (s *requestSpan) retry() {
+	if s != nil {
+		atomic.AddInt32(&s.retries, 1)
+	}
+}
+
+// countBody counts the bytes read from the response body
+func (s *requestSpan) countBody(resp *http.Response) {
+	if s != nil && resp != nil && resp.Body != nil {
+		resp.Body = &countingBody{ReadCloser: resp.Body, count: &s.responseBytes}
+	}
+}
+
+// end notifies the instrumentation that the request is done
+func (s *requestSpan) end(resp *Response, err error) {
+	if s == nil {
+		return
+	}
+	result := RequestResult{
+		Retries:       int(atomic.LoadInt32(&s.retries)),
+		RequestBytes:  s.requestBytes,
+		ResponseBytes: atomic.LoadInt64(&s.responseBytes),
+		Duration:      time.Since(s.start),
+		Err:           err,
+	}
+	if resp != nil && resp.Response != nil {
+		result.StatusCode = resp.StatusCode
+		if result.ResponseBytes == 0 && resp.ContentLength != 0 {
+			// The body was not read by the client
+			result.ResponseBytes = resp.ContentLength
+		}
+	}
+	s.instrumentation.EndRequest(s.ctx, s.info, result)
+}
+
+// countingBody is a response body counting the bytes read
+type countingBody struct {
+	io.ReadCloser
+	count *int64
+}
+
+// Read reads from the body and counts the bytes read
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	atomic.AddInt64(b.count, int64(n))
+	return n, err
+}
+
+// endpointTemplate returns the service name and the templated endpoint of an URL path.
+// API v3 paths alternate collections and IDs, IDs are replaced by {id} unless they are keywords like me.
+// Other paths only get their numeric segments replaced.
+func (c *Client) endpointTemplate(path string) (string, string) {
+	path = strings.TrimPrefix(path, c.baseURL.Path)
+	path = strings.Trim(path, "/")
+	if !strings.HasPrefix(path, apiPathPrefix) {
+		segments := strings.Split(path, "/")
+		for i, segment := range segments {
+			if numericSegment.MatchString(segment) {
+				segments[i] = "{id}"
+			}
+		}
+		return "", strings.Join(segments, "/")
+	}
+
+	segments := strings.Split(strings.TrimPrefix(path, apiPathPrefix), "/")
+	service := ""
+	isID := false
+	for i, segment := range segments {
+		switch {
+		case isID && endpointKeywords[segment]:
+			// e.g. api/v3/time_entries/activities/{id} still belongs to the time entries
+			continue
+		case isID:
+			segments[i] = "{id}"
+			isID = false
+			continue
+		case endpointServices[segment] != "":
+			service = endpointServices[segment]
+		case service == "":
+			service = segment
+		}
+		isID = true
+	}
+	return service, apiPathPrefix + strings.Join(segments, "/")
+}
@@ -0,0 +1,117 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// recordingInstrumentation records the events it receives
+type recordingInstrumentation struct {
+	mu      sync.Mutex
+	started []RequestInfo
+	ended   []RequestResult
+}
+
+func (r *recordingInstrumentation) StartRequest(ctx context.Context, info RequestInfo) context.Context {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started = append(r.started, info)
+	return ctx
+}
+
+func (r *recordingInstrumentation) EndRequest(ctx context.Context, info RequestInfo, result RequestResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ended = append(r.ended, result)
+}
+
+func TestClient_Instrumentation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	body := `{"_type":"Status","id":1,"name":"New"}`
+	attempts := 0
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, body)
+	})
+
+	recorder := &recordingInstrumentation{}
+	c, _ := NewClient(nil, testServer.URL, WithInstrumentation(recorder), WithRetryPolicy(testRetryPolicy()))
+	if _, _, err := c.Status.Get("1"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	if len(recorder.started) != 1 || len(recorder.ended) != 1 {
+		t.Fatalf("Expected a single request. Got %d started, %d ended", len(recorder.started), len(recorder.ended))
+	}
+	info, result := recorder.started[0], recorder.ended[0]
+	if info.Service != "Status" || info.Method != "GET" || info.Endpoint != "api/v3/statuses/{id}" {
+		t.Errorf("Unexpected request info %+v", info)
+	}
+	if result.StatusCode != http.StatusOK || result.Retries != 1 || result.Err != nil {
+		t.Errorf("Unexpected request result %+v", result)
+	}
+	if result.ResponseBytes != int64(len(body)) {
+		t.Errorf("Expected %d response bytes. Got %d", len(body), result.ResponseBytes)
+	}
+}
+
+func TestClient_Instrumentation_Error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/api/v3/projects/demo-project/work_packages", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	recorder := &recordingInstrumentation{}
+	c, _ := NewClient(nil, testServer.URL, WithInstrumentation(recorder))
+	_, _, err := c.WorkPackage.Create(&WorkPackage{Subject: "Test"}, "demo-project")
+	if err == nil {
+		t.Fatal("Expected error, but no error given")
+	}
+
+	info, result := recorder.started[0], recorder.ended[0]
+	if info.Service != "WorkPackage" || info.Endpoint != "api/v3/projects/{id}/work_packages" {
+		t.Errorf("Unexpected request info %+v", info)
+	}
+	if result.StatusCode != http.StatusForbidden || result.Err == nil || result.RequestBytes <= 0 {
+		t.Errorf("Unexpected request result %+v", result)
+	}
+}
+
+func TestClient_EndpointTemplate(t *testing.T) {
+	c, _ := NewClient(nil, "https://openproject.example.com/openproject/")
+	tests := []struct {
+		path     string
+		service  string
+		endpoint string
+	}{
+		{"/openproject/api/v3/work_packages/42", "WorkPackage", "api/v3/work_packages/{id}"},
+		{"/openproject/api/v3/work_packages/42/activities", "Activities", "api/v3/work_packages/{id}/activities"},
+		{"/openproject/api/v3/projects/demo-project/work_packages", "WorkPackage", "api/v3/projects/{id}/work_packages"},
+		{"/openproject/api/v3/projects/demo-project/queries/default", "Query", "api/v3/projects/{id}/queries/default"},
+		{"/openproject/api/v3/users/me", "User", "api/v3/users/me"},
+		{"/openproject/api/v3/work_packages/schemas/3-1", "WorkPackage", "api/v3/work_packages/schemas/{id}"},
+		{"/openproject/api/v3/attachments/12/content", "Attachment", "api/v3/attachments/{id}/content"},
+		{"/openproject/api/v3/statuses", "Status", "api/v3/statuses"},
+		{"/openproject/api/v3/activities/5", "Activities", "api/v3/activities/{id}"},
+		{"/openproject/api/v3/work_packages/42/activities", "Activities", "api/v3/work_packages/{id}/activities"},
+		{"/openproject/api/v3/time_entries/activities/1", "TimeEntry", "api/v3/time_entries/activities/{id}"},
+		{"/openproject/rest/auth/1/session", "", "rest/auth/{id}/session"},
+	}
+	for _, test := range tests {
+		service, endpoint := c.endpointTemplate(test.path)
+		if service != test.service || endpoint != test.endpoint {
+			t.Errorf("%s: expected %s %s. Got %s %s", test.path, test.service, test.endpoint, service, endpoint)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package openproject
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LimiterStats reports the state of the client side rate limit and concurrency cap
+type LimiterStats struct {
+	// InFlight is the number of requests currently being sent
+	InFlight int
+	// MaxInFlight is the concurrency cap, 0 means unlimited
+	MaxInFlight int
+	// Waiting is the number of requests currently queued for a token or a free slot
+	Waiting int
+	// Tokens is the number of requests that can be sent right now without waiting for the rate limit
+	Tokens float64
+	// Requests is the total number of requests admitted
+	Requests int64
+	// Throttled is the total number of requests that had to wait
+	Throttled int64
+	// TotalWait is the total time requests spent waiting
+	TotalWait time.Duration
+}
+
+// limiter implements a token bucket rate limit and a max-in-flight semaphore shared by every service of a Client
+type limiter struct {
+	mu sync.Mutex
+
+	// Token bucket, rate 0 means unlimited
+	rate   float64
+	burst  int
+	tokens float64
+	last   time.Time
+
+	// Semaphore, nil means unlimited
+	slots       chan struct{}
+	maxInFlight int
+
+	waiting   int
+	requests  int64
+	throttled int64
+	totalWait time.Duration
+}
+
+// WithRateLimit limits the requests sent by the Client to requestsPerSecond, allowing bursts of burst requests.
+// Requests exceeding the limit wait for their turn, or until their context is cancelled.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) {
+		l := c.ensureLimiter()
+		if burst < 1 {
+			burst = 1
+		}
+		l.rate = requestsPerSecond
+		l.burst = burst
+		l.tokens = float64(burst)
+		l.last = time.Now()
+	}
+}
+
+// WithMaxInFlight limits the number of requests the Client sends concurrently.
+// Requests exceeding the limit wait for a free slot, or until their context is cancelled.
+func WithMaxInFlight(maxInFlight int) ClientOption {
+	return func(c *Client) {
+		if maxInFlight < 1 {
+			return
+		}
+		l := c.ensureLimiter()
+		l.slots = make(chan struct{}, maxInFlight)
+		l.maxInFlight = maxInFlight
+	}
+}
+
+// LimiterStats returns the current state of the rate limit and concurrency cap, e.g. to log saturation.
+func (c *Client) LimiterStats() LimiterStats {
+	return c.limiter.stats()
+}
+
+// ensureLimiter returns the limiter of the client, creating it if needed
+func (c *Client) ensureLimiter() *limiter {
+	if c.limiter == nil {
+		c.limiter = &limiter{}
+	}
+	return c.limiter
+}
+
+// do sends a single request through the underlying http client once the limiter admits it.
+// The slot is held until the response body is closed, as the body is still streamed from the server until then.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	release, err := c.limiter.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseBody releases the limiter slot of a response once its body is closed
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// acquire waits for a rate limit token and a free slot. The returned func releases the slot.
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	start := time.Now()
+	l.mu.Lock()
+	l.waiting++
+	l.mu.Unlock()
+
+	err := l.waitToken(ctx)
+	if err == nil && l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+
+	wait := time.Since(start)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.waiting--
+	if err != nil {
+		return nil, err
+	}
+	l.requests++
+	// Waits shorter than a millisecond are just the cost of acquiring
+	if wait > time.Millisecond {
+		l.throttled++
+		l.totalWait += wait
+	}
+
+	release := func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+	return release, nil
+}
+
+// waitToken takes a token from the bucket, waiting for it to be refilled if needed
+func (l *limiter) waitToken(ctx context.Context) error {
+	l.mu.Lock()
+	if l.rate <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	l.refill(time.Now())
+	// Reserve the token, the bucket goes negative while requests queue up
+	l.tokens--
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the reserved token back
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// refill adds the tokens earned since the last refill. l.mu must be held.
+func (l *limiter) refill(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+	l.last = now
+}
+
+// stats returns a snapshot of the limiter state
+func (l *limiter) stats() LimiterStats {
+	if l == nil {
+		return LimiterStats{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := LimiterStats{
+		InFlight:    len(l.slots),
+		MaxInFlight: l.maxInFlight,
+		Waiting:     l.waiting,
+		Requests:    l.requests,
+		Throttled:   l.throttled,
+		TotalWait:   l.totalWait,
+	}
+	if l.rate > 0 {
+		l.refill(time.Now())
+		stats.Tokens = l.tokens
+		if stats.Tokens < 0 {
+			stats.Tokens = 0
+		}
+	}
+	return stats
+}
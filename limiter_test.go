@@ -0,0 +1,144 @@
+package openproject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_MaxInFlight(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var inFlight, maxSeen int32
+	testMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&maxSeen)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxSeen, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		fmt.Fprint(w, `{}`)
+	})
+
+	c, _ := NewClient(nil, testServer.URL, WithMaxInFlight(2))
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := c.NewRequest("GET", "/", nil)
+			resp, err := c.Do(req, nil)
+			if err != nil {
+				t.Errorf("Error given: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxSeen > 2 {
+		t.Errorf("Expected at most 2 requests in flight. Got %d", maxSeen)
+	}
+	stats := c.LimiterStats()
+	if stats.Requests != 6 || stats.InFlight != 0 || stats.MaxInFlight != 2 {
+		t.Errorf("Unexpected limiter stats %+v", stats)
+	}
+	if stats.Throttled == 0 {
+		t.Errorf("Expected some requests to wait. Got %+v", stats)
+	}
+}
+
+func TestClient_MaxInFlight_HeldUntilBodyClosed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	c, _ := NewClient(nil, testServer.URL, WithMaxInFlight(1))
+	req, _ := c.NewRequest("GET", "/", nil)
+	resp, err := c.Do(req, nil)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if stats := c.LimiterStats(); stats.InFlight != 1 {
+		t.Errorf("Expected the slot to be held while the body is open. Got %+v", stats)
+	}
+
+	// A second request waits for the body of the first one to be closed
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ = c.NewRequestWithContext(ctx, "GET", "/", nil)
+	if _, err := c.Do(req, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the second request to wait for a slot. Got %v", err)
+	}
+
+	resp.Body.Close()
+	resp.Body.Close()
+	if stats := c.LimiterStats(); stats.InFlight != 0 {
+		t.Errorf("Expected the slot to be released once the body is closed. Got %+v", stats)
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	c, _ := NewClient(nil, testServer.URL, WithRateLimit(50, 2))
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		req, _ := c.NewRequest("GET", "/", nil)
+		if _, err := c.Do(req, nil); err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+	}
+	// 2 requests of burst, the other 3 wait 20ms each
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected requests to be rate limited. Took %s", elapsed)
+	}
+}
+
+func TestClient_RateLimit_ContextCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	c, _ := NewClient(nil, testServer.URL, WithRateLimit(0.1, 1))
+	req, _ := c.NewRequest("GET", "/", nil)
+	if _, err := c.Do(req, nil); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ = c.NewRequestWithContext(ctx, "GET", "/", nil)
+	start := time.Now()
+	_, err := c.Do(req, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded. Got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the queued request to stop on cancellation. Took %s", elapsed)
+	}
+	if stats := c.LimiterStats(); stats.Waiting != 0 || stats.Requests != 1 {
+		t.Errorf("Unexpected limiter stats %+v", stats)
+	}
+}
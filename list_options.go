@@ -0,0 +1,132 @@
+package openproject
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Constants to represent OpenProject collection GET parameters
+const (
+	paramSortBy     = "sortBy"
+	paramGroupBy    = "groupBy"
+	paramShowSums   = "showSums"
+	paramSelect     = "select"
+	paramTimestamps = "timestamps"
+)
+
+// selectPagination are the collection properties always selected so paging keeps working
+var selectPagination = []string{"total", "count"}
+
+// SortKey is a property collections can be sorted by
+type SortKey string
+
+// Common sort keys, any other sortable property name can be used as SortKey
+const (
+	SortByID          SortKey = "id"
+	SortBySubject     SortKey = "subject"
+	SortByName        SortKey = "name"
+	SortByStatus      SortKey = "status"
+	SortByPriority    SortKey = "priority"
+	SortByType        SortKey = "type"
+	SortByAssignee    SortKey = "assignee"
+	SortByParent      SortKey = "parent"
+	SortByStartDate   SortKey = "startDate"
+	SortByDueDate     SortKey = "dueDate"
+	SortByCreatedAt   SortKey = "createdAt"
+	SortByUpdatedAt   SortKey = "updatedAt"
+	SortByManualOrder SortKey = "manualSorting"
+)
+
+// SortDirection is the direction of a sort criterion
+type SortDirection string
+
+const (
+	// Asc sorts in ascending order
+	Asc SortDirection = "asc"
+	// Desc sorts in descending order
+	Desc SortDirection = "desc"
+)
+
+// SortBy is a sort criterion of a collection
+type SortBy struct {
+	Key       SortKey
+	Direction SortDirection
+}
+
+// ListOptions are the collection parameters besides filters and pagination.
+// They are given to list methods within FilterOptions.
+// Usage case:
+//
+//	opt := &FilterOptions{ListOptions: ListOptions{
+//		SortBy: []SortBy{{SortByUpdatedAt, Desc}},
+//		Select: []string{"elements/id", "elements/subject"},
+//	}}
+type ListOptions struct {
+	// SortBy criteria, the first one takes precedence
+	SortBy []SortBy
+	// GroupBy is the property to group elements by, e.g. status. Groups are returned along with the elements.
+	GroupBy string
+	// ShowSums requests the sums of summable properties, per group if grouped
+	ShowSums bool
+	// Select restricts the returned properties (sparse fieldsets), e.g. elements/id and elements/subject.
+	// The collection total and count are always selected so paging keeps working.
+	Select []string
+	// Timestamps requests the elements as they were at given points in time, like 2023-01-01T00:00:00Z or P-1W
+	// (ISO 8601 date-times or durations relative to now), to compare them with the current state (baseline).
+	Timestamps []string
+}
+
+// prepare adds the collection parameters to values
+func (lops *ListOptions) prepare(values url.Values) error {
+	if len(lops.SortBy) > 0 {
+		criteria := make([][2]string, 0, len(lops.SortBy))
+		for _, sortBy := range lops.SortBy {
+			direction := sortBy.Direction
+			if direction == "" {
+				direction = Asc
+			}
+			criteria = append(criteria, [2]string{string(sortBy.Key), string(direction)})
+		}
+		sortBy, err := json.Marshal(criteria)
+		if err != nil {
+			return err
+		}
+		values.Set(paramSortBy, string(sortBy))
+	}
+	if lops.GroupBy != "" {
+		values.Set(paramGroupBy, lops.GroupBy)
+	}
+	if lops.ShowSums {
+		values.Set(paramShowSums, strconv.FormatBool(true))
+	}
+	if len(lops.Select) > 0 {
+		values.Set(paramSelect, strings.Join(lops.selectProperties(), ","))
+	}
+	if len(lops.Timestamps) > 0 {
+		values.Set(paramTimestamps, strings.Join(lops.Timestamps, ","))
+	}
+	return nil
+}
+
+// selectProperties returns the selected properties along with the pagination ones
+func (lops *ListOptions) selectProperties() []string {
+	properties := make([]string, 0, len(selectPagination)+len(lops.Select))
+	for _, property := range selectPagination {
+		if !containsString(lops.Select, property) {
+			properties = append(properties, property)
+		}
+	}
+	return append(properties, lops.Select...)
+}
+
+// containsString reports whether s is in list
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package openproject
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestWorkPackageService_GetList_ListOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/api/v3/work_packages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		query := r.URL.Query()
+		want := map[string]string{
+			"sortBy":     `[["updatedAt","desc"],["id","asc"]]`,
+			"groupBy":    "status",
+			"showSums":   "true",
+			"timestamps": "P-1W,PT0S",
+			"filters":    `[{"status":{"operator":"o","values":[]}}]`,
+		}
+		for key, value := range want {
+			if got := query.Get(key); got != value {
+				t.Errorf("Expected %s=%s. Got %s", key, value, got)
+			}
+		}
+		fmt.Fprint(w, `{"_type":"WorkPackageCollection","total":3,"count":3,"pageSize":10,"offset":1,
+			"_embedded":{"elements":[]},
+			"groups":[{"_type":"GroupBy","value":"New","count":2,"sums":{"estimatedTime":"PT3H","storyPoints":5},
+				"_links":{"valueLink":[{"href":"/api/v3/statuses/1"}]}},
+				{"_type":"GroupBy","value":"In progress","count":1,"sums":{"estimatedTime":"PT1H","storyPoints":2}}],
+			"totalSums":{"estimatedTime":"PT4H","storyPoints":7}}`)
+	})
+
+	opt := NewFilterOptions(FilterStatus(Open))
+	opt.ListOptions = ListOptions{
+		SortBy:     []SortBy{{SortByUpdatedAt, Desc}, {Key: SortByID}},
+		GroupBy:    "status",
+		ShowSums:   true,
+		Timestamps: []string{"P-1W", "PT0S"},
+	}
+	wps, _, err := testClient.WorkPackage.GetList(opt, 1, 10)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(wps.Groups) != 2 || wps.Groups[0].Value != "New" || wps.Groups[0].Count != 2 {
+		t.Errorf("Unexpected groups %+v", wps.Groups)
+	}
+	if wps.Groups[0].Links.ValueLink[0].Href != "/api/v3/statuses/1" {
+		t.Errorf("Unexpected group value link %+v", wps.Groups[0].Links)
+	}
+	if wps.TotalSums["storyPoints"] != float64(7) || wps.Groups[1].Sums["estimatedTime"] != "PT1H" {
+		t.Errorf("Unexpected sums %+v %+v", wps.TotalSums, wps.Groups[1].Sums)
+	}
+}
+
+func TestWorkPackageService_GetList_Select(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/api/v3/work_packages", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("select"); got != "total,count,elements/id,elements/subject" {
+			t.Errorf("Unexpected select %s", got)
+		}
+		if got := query.Get("filters"); got != "[]" {
+			t.Errorf("Expected empty filters to be sent. Got %s", got)
+		}
+		fmt.Fprint(w, `{"total":25,"count":10,"_embedded":{"elements":[{"id":1,"subject":"First"}]}}`)
+	})
+
+	opt := &FilterOptions{ListOptions: ListOptions{Select: []string{"elements/id", "elements/subject"}}}
+	wps, resp, err := testClient.WorkPackage.GetList(opt, 2, 10)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if wps.Embedded.Elements[0].Subject != "First" {
+		t.Errorf("Unexpected elements %+v", wps.Embedded.Elements)
+	}
+	if wps.TotalPage() != 3 || resp.PageSize != 10 || resp.Offset != 2 {
+		t.Errorf("Expected paging values from the request. Got %+v, %d pages", wps.PaginationParam, wps.TotalPage())
+	}
+}
@@ -0,0 +1,303 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// MembershipService handles project memberships for the OpenProject instance / API.
+// A membership grants roles in a project to a principal: a user, a group or a placeholder user.
+type MembershipService struct {
+	client *Client
+}
+
+// Membership is the object representing OpenProject project memberships
+type Membership struct {
+	Type      string          `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID        int             `json:"id,omitempty" structs:"id,omitempty"`
+	CreatedAt *Time           `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	UpdatedAt *Time           `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+	Meta      *MembershipMeta `json:"_meta,omitempty" structs:"_meta,omitempty"`
+	Links     MembershipLinks `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// MembershipLinks are Membership Links
+type MembershipLinks struct {
+	Self              *OPGenericLink  `json:"self,omitempty" structs:"self,omitempty"`
+	Schema            *OPGenericLink  `json:"schema,omitempty" structs:"schema,omitempty"`
+	Update            *OPGenericLink  `json:"update,omitempty" structs:"update,omitempty"`
+	UpdateImmediately *OPGenericLink  `json:"updateImmediately,omitempty" structs:"updateImmediately,omitempty"`
+	Project           *OPGenericLink  `json:"project,omitempty" structs:"project,omitempty"`
+	Principal         *OPGenericLink  `json:"principal,omitempty" structs:"principal,omitempty"`
+	Roles             []OPGenericLink `json:"roles,omitempty" structs:"roles,omitempty"`
+}
+
+// MembershipMeta holds the notification sent to the principal when a membership is created or updated
+type MembershipMeta struct {
+	NotificationMessage *OPGenericDescription `json:"notificationMessage,omitempty" structs:"notificationMessage,omitempty"`
+	SendNotifications   *bool                 `json:"sendNotifications,omitempty" structs:"sendNotifications,omitempty"`
+}
+
+// NewMembership returns a membership granting roles in a project to a principal, ready to be created.
+// Users, groups and placeholder users share the same IDs, so principalID can be any of them.
+func NewMembership(projectID string, principalID string, roleIDs ...string) *Membership {
+	membership := &Membership{}
+	membership.Links.Project = resourceLink("projects", projectID)
+	membership.Links.Principal = resourceLink("users", principalID)
+	membership.SetRoles(roleIDs...)
+	return membership
+}
+
+// SetRoles replaces the roles of the membership
+func (m *Membership) SetRoles(roleIDs ...string) *Membership {
+	m.Links.Roles = make([]OPGenericLink, 0, len(roleIDs))
+	for _, roleID := range roleIDs {
+		m.Links.Roles = append(m.Links.Roles, *resourceLink("roles", roleID))
+	}
+	return m
+}
+
+// Notify sends message to the principal along with the membership notification.
+// An empty message disables the notification. Without Notify, OpenProject sends its default notification.
+func (m *Membership) Notify(message string) *Membership {
+	send := message != ""
+	m.Meta = &MembershipMeta{SendNotifications: &send}
+	if send {
+		m.Meta.NotificationMessage = &OPGenericDescription{Format: "markdown", Raw: message}
+	}
+	return m
+}
+
+// RoleIDs returns the IDs of the roles granted by the membership
+func (m *Membership) RoleIDs() []string {
+	ids := make([]string, 0, len(m.Links.Roles))
+	for i := range m.Links.Roles {
+		ids = append(ids, m.Links.Roles[i].LinkID())
+	}
+	return ids
+}
+
+// SearchResultMembership represent a list of memberships
+type SearchResultMembership struct {
+	Embedded membershipElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	PaginationParam
+}
+
+func (s *SearchResultMembership) TotalPage() int {
+	return int(math.Ceil(float64(s.Total) / float64(s.PageSize)))
+}
+
+func (s *SearchResultMembership) ConcatEmbed(memberships interface{}) {
+	s.Embedded.Elements = append(s.Embedded.Elements, memberships.(*SearchResultMembership).Embedded.Elements...)
+}
+
+// Elements returns the memberships of the page
+func (s *SearchResultMembership) Elements() []Membership {
+	return s.Embedded.Elements
+}
+
+// membershipElements array wraps elements within SearchResultMembership
+type membershipElements struct {
+	Elements []Membership `json:"elements,omitempty" structs:"elements,omitempty"`
+}
+
+// FilterPrincipal filters memberships by principal, e.g. FilterPrincipal(5)
+func FilterPrincipal(args ...interface{}) Filter {
+	return fieldFilter("principal", args)
+}
+
+// FilterRole filters memberships by role, e.g. FilterRole(3)
+func FilterRole(args ...interface{}) Filter {
+	return fieldFilter("role", args)
+}
+
+// GetWithContext gets a membership from OpenProject using its ID
+func (s *MembershipService) GetWithContext(ctx context.Context, membershipID string) (*Membership, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/memberships/%s", membershipID)
+	obj, resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Membership), resp, err
+}
+
+// Get wraps GetWithContext using the background context.
+func (s *MembershipService) Get(membershipID string) (*Membership, *Response, error) {
+	return s.GetWithContext(context.Background(), membershipID)
+}
+
+// GetListWithContext retrieves the memberships visible to the user, which can be filtered by project, principal or role
+func (s *MembershipService) GetListWithContext(ctx context.Context, options *FilterOptions, offset int, pageSize int) (*SearchResultMembership, *Response, error) {
+	apiEndpoint := "api/v3/memberships"
+	obj, resp, err := GetListWithContext(ctx, s, apiEndpoint, options, offset, pageSize)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*SearchResultMembership), resp, err
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *MembershipService) GetList(options *FilterOptions, offset int, pageSize int) (*SearchResultMembership, *Response, error) {
+	return s.GetListWithContext(context.Background(), options, offset, pageSize)
+}
+
+// CreateWithContext creates a membership, see NewMembership. The principal is notified by email,
+// with the message set by Notify if any.
+func (s *MembershipService) CreateWithContext(ctx context.Context, membership *Membership) (*Membership, *Response, error) {
+	apiEndpoint := "api/v3/memberships"
+	obj, resp, err := CreateWithContext(ctx, membership, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Membership), resp, err
+}
+
+// Create wraps CreateWithContext using the background context.
+func (s *MembershipService) Create(membership *Membership) (*Membership, *Response, error) {
+	return s.CreateWithContext(context.Background(), membership)
+}
+
+// UpdateWithContext replaces the roles of a membership. Its project and principal can not be changed.
+func (s *MembershipService) UpdateWithContext(ctx context.Context, membershipID string, membership *Membership) (*Membership, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/memberships/%s", membershipID)
+	obj, resp, err := UpdateWithContext(ctx, membership, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Membership), resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *MembershipService) Update(membershipID string, membership *Membership) (*Membership, *Response, error) {
+	return s.UpdateWithContext(context.Background(), membershipID, membership)
+}
+
+// DeleteWithContext deletes a membership, removing the principal from the project
+func (s *MembershipService) DeleteWithContext(ctx context.Context, membershipID string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/memberships/%s", membershipID)
+	return DeleteWithContext(ctx, s, apiEndpoint)
+}
+
+// Delete wraps DeleteWithContext using the background context.
+func (s *MembershipService) Delete(membershipID string) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), membershipID)
+}
+
+// MembershipSyncOptions tune SyncProject
+type MembershipSyncOptions struct {
+	// Prune deletes the memberships of the principals which are not desired. They are kept if false.
+	Prune bool
+
+	// NotificationMessage is sent to the principals added to the project along with the notification.
+	// They get the default notification of OpenProject if empty.
+	NotificationMessage string
+
+	// SkipNotifications adds principals to the project without notifying them
+	SkipNotifications bool
+}
+
+// MembershipSyncResult is what SyncProject changed
+type MembershipSyncResult struct {
+	Created   []Membership
+	Updated   []Membership
+	Deleted   []Membership
+	Unchanged []Membership
+}
+
+// SyncProjectWithContext makes the memberships of a project match desired, a map of principal IDs to role IDs.
+// Missing memberships are created, memberships with other roles are updated, and those of principals mapped to no role
+// are deleted, as well as the ones of principals not in desired if opts.Prune is set.
+// Running it again with the same arguments changes nothing. On error, the changes already made are returned.
+//
+// Users who are members only through a group have a membership of their own, with inherited roles, which OpenProject
+// refuses to change or delete: keep them out of desired (without Prune), or manage the membership of their group.
+// Usage case:
+//
+//	result, err := client.Membership.SyncProject("demo-project", map[string][]string{"5": {"3"}, "8": {"3", "4"}}, nil)
+func (s *MembershipService) SyncProjectWithContext(ctx context.Context, projectID string, desired map[string][]string,
+	opts *MembershipSyncOptions) (*MembershipSyncResult, error) {
+	if opts == nil {
+		opts = new(MembershipSyncOptions)
+	}
+
+	existing := make(map[string]Membership)
+	it := NewPager[Membership](WithFilter(NewFilterOptions(FilterProject(projectID)), s.GetListWithContext)).Iterate(ctx)
+	defer it.Close()
+	for it.Next() {
+		membership := it.Value()
+		existing[membership.Links.Principal.LinkID()] = membership
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	result := new(MembershipSyncResult)
+	principalIDs := make([]string, 0, len(desired)+len(existing))
+	for principalID := range desired {
+		principalIDs = append(principalIDs, principalID)
+	}
+	for principalID := range existing {
+		if _, ok := desired[principalID]; !ok {
+			principalIDs = append(principalIDs, principalID)
+		}
+	}
+	sort.Strings(principalIDs)
+
+	for _, principalID := range principalIDs {
+		roleIDs, wanted := desired[principalID]
+		current, member := existing[principalID]
+		switch {
+		case !member && len(roleIDs) > 0:
+			membership := NewMembership(projectID, principalID, roleIDs...)
+			if opts.SkipNotifications {
+				membership.Notify("")
+			} else if opts.NotificationMessage != "" {
+				membership.Notify(opts.NotificationMessage)
+			}
+			created, _, err := s.CreateWithContext(ctx, membership)
+			if err != nil {
+				return result, err
+			}
+			result.Created = append(result.Created, *created)
+		case !member:
+		case len(roleIDs) == 0 && (wanted || opts.Prune):
+			if _, err := s.DeleteWithContext(ctx, fmt.Sprint(current.ID)); err != nil {
+				return result, err
+			}
+			result.Deleted = append(result.Deleted, current)
+		case !wanted || sameIDs(current.RoleIDs(), roleIDs):
+			result.Unchanged = append(result.Unchanged, current)
+		default:
+			patch := new(Membership).SetRoles(roleIDs...)
+			updated, _, err := s.UpdateWithContext(ctx, fmt.Sprint(current.ID), patch)
+			if err != nil {
+				return result, err
+			}
+			result.Updated = append(result.Updated, *updated)
+		}
+	}
+	return result, nil
+}
+
+// SyncProject wraps SyncProjectWithContext using the background context.
+func (s *MembershipService) SyncProject(projectID string, desired map[string][]string, opts *MembershipSyncOptions) (*MembershipSyncResult, error) {
+	return s.SyncProjectWithContext(context.Background(), projectID, desired, opts)
+}
+
+// sameIDs reports whether both lists hold the same IDs, whatever their order
+func sameIDs(a []string, b []string) bool {
+	set := make(map[string]bool, len(a))
+	for _, id := range a {
+		set[id] = true
+	}
+	other := make(map[string]bool, len(b))
+	for _, id := range b {
+		if !set[id] {
+			return false
+		}
+		other[id] = true
+	}
+	return len(set) == len(other)
+}
@@ -0,0 +1,169 @@
+package openproject
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestMembershipService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/memberships/11", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/api/v3/memberships/11")
+		fmt.Fprint(w, `{"_type":"Membership","id":11,"_links":{"principal":{"href":"/api/v3/users/5"},
+			"roles":[{"href":"/api/v3/roles/3"},{"href":"/api/v3/roles/4"}]}}`)
+	})
+
+	membership, _, err := testClient.Membership.Get("11")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if membership.Links.Principal.LinkID() != "5" || !reflect.DeepEqual(membership.RoleIDs(), []string{"3", "4"}) {
+		t.Errorf("Unexpected membership %+v", membership)
+	}
+}
+
+func TestMembershipService_GetList(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-memberships-filtered.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/memberships", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		want := `[{"project":{"operator":"=","values":["3"]}},{"role":{"operator":"=","values":["3"]}}]`
+		if got := r.URL.Query().Get("filters"); got != want {
+			t.Errorf("Expected filters %s. Got %s", want, got)
+		}
+		fmt.Fprint(w, string(raw))
+	})
+
+	memberships, resp, err := testClient.Membership.GetList(NewFilterOptions(FilterProject(3), FilterRole(3)), 1, 100)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(memberships.Elements()) != 3 || resp.Total != 3 {
+		t.Errorf("Expected 3 memberships. Got %d", len(memberships.Elements()))
+	}
+}
+
+func TestMembershipService_CreateWithNotification(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/memberships", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		wantLinks := `{"project":{"href":"/api/v3/projects/3"},"principal":{"href":"/api/v3/users/5"},"roles":[{"href":"/api/v3/roles/3"}]}`
+		if string(body["_links"]) != wantLinks {
+			t.Errorf("Expected links %s. Got %s", wantLinks, body["_links"])
+		}
+		wantMeta := `{"notificationMessage":{"format":"markdown","raw":"Welcome aboard!"},"sendNotifications":true}`
+		if string(body["_meta"]) != wantMeta {
+			t.Errorf("Expected meta %s. Got %s", wantMeta, body["_meta"])
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"_type":"Membership","id":14}`)
+	})
+
+	membership, _, err := testClient.Membership.Create(NewMembership("3", "5", "3").Notify("Welcome aboard!"))
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if membership.ID != 14 {
+		t.Errorf("Expected membership 14. Got %d", membership.ID)
+	}
+}
+
+func TestMembershipService_SyncProject(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-memberships-filtered.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	var created, updated, deleted, metas []string
+	testMux.HandleFunc("/api/v3/memberships", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, string(raw))
+		case "POST":
+			membership := new(Membership)
+			if err := json.NewDecoder(r.Body).Decode(membership); err != nil {
+				t.Error(err)
+			}
+			created = append(created, membership.Links.Principal.LinkID())
+			meta, _ := json.Marshal(membership.Meta)
+			metas = append(metas, string(meta))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"_type":"Membership","id":20}`)
+		}
+	})
+	testMux.HandleFunc("/api/v3/memberships/", func(w http.ResponseWriter, r *http.Request) {
+		id := lastPathSegment(r.URL.Path)
+		switch r.Method {
+		case "PATCH":
+			membership := new(Membership)
+			if err := json.NewDecoder(r.Body).Decode(membership); err != nil {
+				t.Error(err)
+			}
+			updated = append(updated, fmt.Sprint(id, membership.RoleIDs()))
+			fmt.Fprintf(w, `{"_type":"Membership","id":%s}`, id)
+		case "DELETE":
+			deleted = append(deleted, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	desired := map[string][]string{
+		"5":  {"3"},      // unchanged
+		"7":  {"3", "4"}, // roles changed
+		"9":  {},         // removed
+		"21": {"4"},      // added
+		"22": nil,        // not a member, nothing to do
+	}
+	result, err := testClient.Membership.SyncProject("3", desired, &MembershipSyncOptions{NotificationMessage: "Welcome"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !reflect.DeepEqual(created, []string{"21"}) || !reflect.DeepEqual(updated, []string{"12[3 4]"}) ||
+		!reflect.DeepEqual(deleted, []string{"13"}) {
+		t.Errorf("Unexpected changes: created %v, updated %v, deleted %v", created, updated, deleted)
+	}
+	if len(result.Created) != 1 || len(result.Updated) != 1 || len(result.Deleted) != 1 || len(result.Unchanged) != 1 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	// Members not desired are only removed when pruning, and new members get the default notification
+	created, updated, deleted, metas = nil, nil, nil, nil
+	desired = map[string][]string{"5": {"3"}, "21": {"4"}}
+	result, err = testClient.Membership.SyncProject("3", desired, &MembershipSyncOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !reflect.DeepEqual(metas, []string{"null"}) {
+		t.Errorf("Expected no _meta without notification message. Got %v", metas)
+	}
+	if len(created) != 1 || len(updated) != 0 || !reflect.DeepEqual(deleted, []string{"12", "13"}) {
+		t.Errorf("Unexpected changes: created %v, updated %v, deleted %v", created, updated, deleted)
+	}
+	if len(result.Unchanged) != 1 {
+		t.Errorf("Expected 1 unchanged membership. Got %+v", result.Unchanged)
+	}
+
+	metas = nil
+	if _, err := testClient.Membership.SyncProject("3", desired, &MembershipSyncOptions{SkipNotifications: true}); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !reflect.DeepEqual(metas, []string{`{"sendNotifications":false}`}) {
+		t.Errorf("Expected notifications to be skipped. Got %v", metas)
+	}
+}
@@ -0,0 +1,88 @@
+package openproject
+
+import (
+	"net/http"
+)
+
+// redactedValue replaces the value of sensitive headers
+const redactedValue = "REDACTED"
+
+// sensitiveHeaders are the headers carrying credentials
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Doer sends an API request and returns the API response.
+// v is the target the response is decoded into (nil for downloads), the Response holds the paging info.
+type Doer interface {
+	Do(req *http.Request, v interface{}) (*Response, error)
+}
+
+// DoerFunc is an adapter to use ordinary functions as Doer
+type DoerFunc func(req *http.Request, v interface{}) (*Response, error)
+
+// Do calls f(req, v)
+func (f DoerFunc) Do(req *http.Request, v interface{}) (*Response, error) {
+	return f(req, v)
+}
+
+// Middleware wraps a Doer to run code around every request sent through Client.Do and Client.Download,
+// like logging, metrics, header injection or request signing.
+// The Response may be nil if the request could not be sent.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds middlewares to the Client. The first one is the outermost.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.Use(middlewares...)
+	}
+}
+
+// Use appends middlewares to the chain of the Client. The first one is the outermost.
+// It must not be called while the client is in use.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// chain wraps last with the middlewares of the client
+func (c *Client) chain(last Doer) Doer {
+	doer := last
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		doer = c.middlewares[i](doer)
+	}
+	return doer
+}
+
+// HeaderMiddleware sets a header on every request
+func HeaderMiddleware(key string, value string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request, v interface{}) (*Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set(key, value)
+			return next.Do(req, v)
+		})
+	}
+}
+
+// RequestIDMiddleware sets the X-Request-Id header of every request not carrying one to a value from generate
+func RequestIDMiddleware(generate func() string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request, v interface{}) (*Response, error) {
+			if req.Header.Get("X-Request-Id") == "" {
+				req = req.Clone(req.Context())
+				req.Header.Set("X-Request-Id", generate())
+			}
+			return next.Do(req, v)
+		})
+	}
+}
+
+// RedactHeaders returns a copy of header with the values of headers carrying credentials
+// (Authorization, Proxy-Authorization, Cookie and Set-Cookie) replaced, so it can be logged safely.
+func RedactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, key := range sensitiveHeaders {
+		if _, ok := redacted[key]; ok {
+			redacted[key] = []string{redactedValue}
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,67 @@
+//go:build go1.21
+
+package openproject
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// LoggingMiddleware logs every request with a structured logger.
+// Requests are logged at Info level, or Error level when they fail. The headers, with credentials redacted,
+// are only added at Debug level.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request, v interface{}) (*Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req, v)
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("url", req.URL.Redacted()),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if v != nil {
+				attrs = append(attrs, slog.String("target", fmt.Sprintf("%T", v)))
+			}
+			if resp != nil {
+				attrs = append(attrs, slog.Int("status", resp.StatusCode))
+				if resp.Total > 0 || resp.Count > 0 {
+					attrs = append(attrs, slog.Group("paging",
+						slog.Int("total", resp.Total),
+						slog.Int("count", resp.Count),
+						slog.Int("pageSize", resp.PageSize),
+						slog.Int("offset", resp.Offset),
+					))
+				}
+			}
+
+			ctx := req.Context()
+			if logger.Enabled(ctx, slog.LevelDebug) {
+				attrs = append(attrs, slog.Any("requestHeaders", RedactHeaders(req.Header)))
+				if resp != nil {
+					attrs = append(attrs, slog.Any("responseHeaders", RedactHeaders(resp.Header)))
+				}
+			}
+
+			level := slog.LevelInfo
+			if err != nil {
+				level = slog.LevelError
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+			logger.LogAttrs(logContext(ctx), level, "openproject request", attrs...)
+			return resp, err
+		})
+	}
+}
+
+// logContext returns the context to log with, it is never nil
+func logContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
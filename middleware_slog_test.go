@@ -0,0 +1,40 @@
+//go:build go1.21
+
+package openproject
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c, _ := NewClient(&http.Client{Transport: &APIKeyAuthTransport{APIKey: "secret"}}, testServer.URL,
+		WithMiddleware(LoggingMiddleware(logger)))
+
+	if _, _, err := c.Status.Get("1"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"method=GET", "status=200", "target=*openproject.Status", "/api/v3/statuses/1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected log to contain %q. Got %s", want, out)
+		}
+	}
+	if strings.Contains(out, "c2VjcmV0") || strings.Contains(out, "apikey:secret") {
+		t.Errorf("Expected credentials to be redacted. Got %s", out)
+	}
+}
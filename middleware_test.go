@@ -0,0 +1,152 @@
+package openproject
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_Middleware_Order(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request, v interface{}) (*Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next.Do(req, v)
+				calls = append(calls, name+" after")
+				return resp, err
+			})
+		}
+	}
+
+	c, _ := NewClient(nil, testServer.URL, WithMiddleware(trace("first"), trace("second")))
+	c.Use(trace("third"))
+	if _, _, err := c.Status.Get("1"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	want := []string{"first before", "second before", "third before", "third after", "second after", "first after"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Expected calls %v. Got %v", want, calls)
+	}
+}
+
+func TestClient_Middleware_TargetAndPaging(t *testing.T) {
+	setup()
+	defer teardown()
+	handleUsersPagination(t, 0, nil)
+
+	var target interface{}
+	var paging *Response
+	inspect := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request, v interface{}) (*Response, error) {
+			resp, err := next.Do(req, v)
+			target, paging = v, resp
+			return resp, err
+		})
+	}
+
+	c, _ := NewClient(nil, testServer.URL, WithMiddleware(inspect))
+	users, _, err := c.User.GetList(nil, 1, 10)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	if result, ok := target.(*SearchResultUser); !ok || result != users {
+		t.Errorf("Expected the decoded *SearchResultUser as target. Got %T", target)
+	}
+	if paging == nil || paging.Total != users.Total || paging.PageSize != users.PageSize {
+		t.Errorf("Expected the paging info of the response. Got %+v", paging)
+	}
+}
+
+func TestClient_Middleware_Download(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/file", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Custom"); got != "value" {
+			t.Errorf("Expected header X-Custom to be sent. Got %q", got)
+		}
+		fmt.Fprint(w, "content")
+	})
+
+	called := false
+	c, _ := NewClient(nil, testServer.URL, WithMiddleware(
+		HeaderMiddleware("X-Custom", "value"),
+		func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request, v interface{}) (*Response, error) {
+				called = true
+				if v != nil {
+					t.Errorf("Expected nil target for downloads. Got %T", v)
+				}
+				return next.Do(req, v)
+			})
+		},
+	))
+
+	req, _ := c.NewRequest("GET", "file", nil)
+	resp, err := c.Download(req)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	resp.Body.Close()
+	if !called {
+		t.Error("Expected Download to go through the middleware chain")
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var ids []string
+	testMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get("X-Request-Id"))
+	})
+
+	c, _ := NewClient(nil, testServer.URL, WithMiddleware(RequestIDMiddleware(func() string { return "generated" })))
+
+	req, _ := c.NewRequest("GET", "/", nil)
+	if _, err := c.Do(req, nil); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	req, _ = c.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-Id", "given")
+	if _, err := c.Do(req, nil); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	if want := []string{"generated", "given"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Expected request ids %v. Got %v", want, ids)
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Basic c2VjcmV0")
+	header.Set("Cookie", "_open_project_session=secret")
+	header.Set("Accept", "application/json")
+
+	redacted := RedactHeaders(header)
+	if got := redacted.Get("Authorization"); got != "REDACTED" {
+		t.Errorf("Expected Authorization to be redacted. Got %s", got)
+	}
+	if got := redacted.Get("Cookie"); got != "REDACTED" {
+		t.Errorf("Expected Cookie to be redacted. Got %s", got)
+	}
+	if got := redacted.Get("Accept"); got != "application/json" {
+		t.Errorf("Expected Accept to be kept. Got %s", got)
+	}
+	if got := header.Get("Authorization"); got != "Basic c2VjcmV0" {
+		t.Errorf("Expected the original header to be left untouched. Got %s", got)
+	}
+}
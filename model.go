@@ -1,5 +1,7 @@
 package openproject
 
+import "encoding/json"
+
 // OPGenericDescription is an structure widely used in several OpenProject API objects
 type OPGenericDescription struct {
 	Format string `json:"format,omitempty" structs:"format,omitempty"`
@@ -7,7 +9,14 @@ type OPGenericDescription struct {
 	HTML   string `json:"html,omitempty" structs:"html,omitempty"`
 }
 
+// TextPatch is the new value of a formattable text property in a patch, like a description.
+// An empty Raw clears the text.
+type TextPatch struct {
+	Raw string `json:"raw" structs:"raw"`
+}
+
 // OPGenericLink is a structure widely used in several OpenProject API objects
+// A link without Href is a null link, sent as {"href": null} to unset a property, e.g. to unassign a work-package.
 type OPGenericLink struct {
 	Href   string `json:"href" structs:"href"`
 	Title  string `json:"title,omitempty" structs:"title,omitempty"`
@@ -15,6 +24,37 @@ type OPGenericLink struct {
 	Type   string `json:"type,omitempty" structs:"type,omitempty"`
 }
 
+// NullLink returns a link unsetting a property
+func NullLink() *OPGenericLink {
+	return &OPGenericLink{}
+}
+
+// IsNull reports whether the link points to nothing
+func (l *OPGenericLink) IsNull() bool {
+	return l == nil || l.Href == ""
+}
+
+// LinkID returns the ID (or identifier) of the linked resource, e.g. 5 for /api/v3/users/5. It is empty for null links.
+func (l *OPGenericLink) LinkID() string {
+	if l.IsNull() {
+		return ""
+	}
+	return lastPathSegment(l.Href)
+}
+
+// MarshalJSON will transform a link without href into a null link
+func (l OPGenericLink) MarshalJSON() ([]byte, error) {
+	type link OPGenericLink
+	var href *string
+	if l.Href != "" {
+		href = &l.Href
+	}
+	return json.Marshal(struct {
+		Href *string `json:"href"`
+		link
+	}{Href: href, link: link(l)})
+}
+
 // PaginationParam is a structure widely used in several OpenProject API objects
 type PaginationParam struct {
 	Total    int `json:"total" structs:"total"`
@@ -23,6 +63,11 @@ type PaginationParam struct {
 	Offset   int `json:"offset" structs:"offset"`
 }
 
+// paginationParam gives access to the paging values of the collections embedding PaginationParam
+func (p *PaginationParam) paginationParam() *PaginationParam {
+	return p
+}
+
 // Schemas is the object representing OpenProject schemas.
 type Schemas struct {
 	Type     string `json:"_type,omitempty" structs:"_type,omitempty"`
@@ -36,6 +81,9 @@ type Schemas struct {
 	}
 }
 
+// SchemaEmbeddedElement describes the properties of a work-package: their type, and whether they are required or writable.
+// It holds no values, so duration properties like EstimatedTime are described like any other property, with the type
+// "Duration". Their values are decoded as Duration on WorkPackage.
 type SchemaEmbeddedElement struct {
 	AttributeGroups []struct {
 		Name      string   `json:"name,omitempty" structs:"name,omitempty"`
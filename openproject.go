@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -36,6 +37,42 @@ const (
 	GreaterOrEqual SearchOperator = ">="
 	// LowerOrEqual operator
 	LowerOrEqual SearchOperator = "<="
+	// NotEqual operator, "is not"
+	NotEqual SearchOperator = "!"
+	// NotLike operator, "doesn't contain"
+	NotLike SearchOperator = "!~"
+	// Open operator, status is open
+	Open SearchOperator = "o"
+	// Closed operator, status is closed
+	Closed SearchOperator = "c"
+	// None operator, property is not set
+	None SearchOperator = "!*"
+	// All operator, property is set to any value
+	All SearchOperator = "*"
+	// AllOf operator, every value is set (multi-value properties)
+	AllOf SearchOperator = "&="
+	// Today operator, date is today
+	Today SearchOperator = "t"
+	// ThisWeek operator, date is within the current week
+	ThisWeek SearchOperator = "w"
+	// OnDate operator, date is the given day
+	OnDate SearchOperator = "=d"
+	// BetweenDates operator, date is between two dates, either of them may be empty
+	BetweenDates SearchOperator = "<>d"
+	// DaysAgo operator, date is the given number of days ago
+	DaysAgo SearchOperator = "t-"
+	// MoreThanDaysAgo operator, date is more than the given number of days ago
+	MoreThanDaysAgo SearchOperator = ">t-"
+	// LessThanDaysAgo operator, date is less than the given number of days ago
+	LessThanDaysAgo SearchOperator = "<t-"
+	// InDays operator, date is in the given number of days
+	InDays SearchOperator = "t+"
+	// InMoreThanDays operator, date is in more than the given number of days
+	InMoreThanDays SearchOperator = ">t+"
+	// InLessThanDays operator, date is in less than the given number of days
+	InLessThanDays SearchOperator = "<t+"
+	// OrderedWorkPackages operator, work-packages in the manual sort order of a query
+	OrderedWorkPackages SearchOperator = "ow"
 )
 
 type IPaginationResponse interface {
@@ -47,17 +84,6 @@ type IPaginationResponse interface {
 const kOffset = "offset"
 const kPageSize = "pageSize"
 
-// Time represents the Time definition of OpenProject as a time.Time of go
-type Time time.Time
-
-// Equal compares time
-func (t Time) Equal(u Time) bool {
-	return time.Time(t).Equal(time.Time(u))
-}
-
-// Date represents the Date definition of OpenProject as a time.Time of go
-type Date time.Time
-
 // httpClient defines an interface for an http.Client implementation
 type httpClient interface {
 	Do(request *http.Request) (response *http.Response, err error)
@@ -74,6 +100,21 @@ type Client struct {
 	// Session storage if the user authenticates with Session cookies
 	session *Session
 
+	// Retry policy applied to every request, nil disables retries
+	retryPolicy *RetryPolicy
+
+	// Rate limit and concurrency cap shared by every service, nil disables them
+	limiter *limiter
+
+	// Middlewares wrapping Do and Download, the first one is the outermost
+	middlewares []Middleware
+
+	// Tracing and metrics hooks, nil disables them
+	instrumentation Instrumentation
+
+	// Cache of GET responses, nil disables it
+	cache *responseCache
+
 	// Services used for talking to different parts of OpenProject API.
 	Authentication *AuthenticationService
 	WorkPackage    *WorkPackageService
@@ -85,11 +126,25 @@ type Client struct {
 	Category       *CategoryService
 	Query          *QueryService
 	Activities     *ActivitiesService
+	TimeEntry      *TimeEntryService
+	Relation       *RelationService
+	Version        *VersionService
+	Type           *TypeService
+	Priority       *PriorityService
+	Membership     *MembershipService
+	Role           *RoleService
+	Principal      *PrincipalService
+	Group          *GroupService
+	Resolver       *Resolver
 }
 
+// ClientOption configures optional behaviour of a Client on creation
+type ClientOption func(*Client)
+
 // NewClient returns a new OpenProject API client.
 // If a nil httpClient is provided, http.DefaultClient will be used.
-func NewClient(httpClient httpClient, baseURL string) (*Client, error) {
+// Optional behaviour like retries can be enabled through options.
+func NewClient(httpClient httpClient, baseURL string, options ...ClientOption) (*Client, error) {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
@@ -118,10 +173,30 @@ func NewClient(httpClient httpClient, baseURL string) (*Client, error) {
 	c.Category = &CategoryService{client: c}
 	c.Query = &QueryService{client: c}
 	c.Activities = &ActivitiesService{client: c}
+	c.TimeEntry = &TimeEntryService{client: c}
+	c.Relation = &RelationService{client: c}
+	c.Version = &VersionService{client: c}
+	c.Type = &TypeService{client: c}
+	c.Priority = &PriorityService{client: c}
+	c.Membership = &MembershipService{client: c}
+	c.Role = &RoleService{client: c}
+	c.Principal = &PrincipalService{client: c}
+	c.Group = &GroupService{client: c}
+	c.Resolver = &Resolver{client: c}
+
+	for _, option := range options {
+		option(c)
+	}
 
 	return c, nil
 }
 
+// NewClientWithAPIKey returns a new OpenProject API client authenticated with an API key (personal access token).
+func NewClientWithAPIKey(baseURL string, apiKey string, options ...ClientOption) (*Client, error) {
+	tp := &APIKeyAuthTransport{APIKey: apiKey}
+	return NewClient(tp.Client(), baseURL, options...)
+}
+
 // NewRequestWithContext creates an API request.
 // A relative URL can be provided in urlStr, in which case it is resolved relative to the baseURL of the Client.
 // If specified, the value pointed to by body is JSON encoded and included as the request body.
@@ -217,8 +292,23 @@ func (c *Client) NewMultiPartRequest(method, urlStr string, buf *bytes.Buffer) (
 
 // Do sends an API request and returns the API response.
 // The API response is JSON decoded and stored in the value pointed to by v, or returned as an error if an API error has occurred.
+// The request goes through the middleware chain of the client.
+// If v is nil the response body is left to the caller, who must close it to free the slot taken by WithMaxInFlight.
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
-	httpResp, err := c.client.Do(req)
+	return c.chain(DoerFunc(c.doRequest)).Do(req, v)
+}
+
+// doRequest sends an API request and decodes the response, it is the last link of the middleware chain of Do
+func (c *Client) doRequest(req *http.Request, v interface{}) (*Response, error) {
+	req, span := c.startRequest(req)
+	resp, err := c.decodeRequest(req, v)
+	span.end(resp, err)
+	return resp, err
+}
+
+// decodeRequest sends an API request and decodes the response into v
+func (c *Client) decodeRequest(req *http.Request, v interface{}) (*Response, error) {
+	httpResp, err := c.sendCached(req)
 	if err != nil {
 		return nil, err
 	}
@@ -247,9 +337,22 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 }
 
 // Download request a file download
+// The request goes through the middleware chain of the client, with a nil target.
+// The caller must close the response body.
 func (c *Client) Download(req *http.Request) (*http.Response, error) {
-	httpResp, err := c.client.Do(req)
+	resp, err := c.chain(DoerFunc(c.downloadRequest)).Do(req, nil)
+	if resp == nil {
+		return nil, err
+	}
+	return resp.Response, err
+}
+
+// downloadRequest sends a download request, it is the last link of the middleware chain of Download
+func (c *Client) downloadRequest(req *http.Request, _ interface{}) (*Response, error) {
+	req, span := c.startRequest(req)
+	httpResp, err := c.send(req)
 	if err != nil {
+		span.end(nil, err)
 		return nil, err
 	}
 
@@ -262,19 +365,39 @@ func (c *Client) Download(req *http.Request) (*http.Response, error) {
 
 	err = CheckResponse(httpResp)
 
-	return httpResp, err
+	resp := newResponse(httpResp, nil)
+	span.end(resp, err)
+	return resp, err
 }
 
 // CheckResponse checks the API response for errors, and returns them if present.
 // A response is considered an error if it has a status code outside the 200 range.
-// The caller is responsible to analyze the response body.
+// The returned error is an *Error decoded from the OpenProject error response.
+// The response body is kept readable so the caller can still analyze it.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; 200 <= c && c <= 299 {
 		return nil
 	}
 
-	err := fmt.Errorf("request failed. Please analyze the request body for more details. Status code: %d", r.StatusCode)
-	return err
+	opErr := &Error{
+		HTTPError:  fmt.Errorf("request failed. Please analyze the request body for more details. Status code: %d", r.StatusCode),
+		StatusCode: r.StatusCode,
+	}
+	if r.Body == nil {
+		return opErr
+	}
+
+	data, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil || len(data) == 0 {
+		return opErr
+	}
+	if isJSONContentType(r.Header.Get("Content-Type")) {
+		// The body is not always an OpenProject error (e.g. proxies), the status code is kept anyway
+		_ = json.Unmarshal(data, opErr)
+	}
+	return opErr
 }
 
 // GetBaseURL will return you the Base URL.
@@ -320,6 +443,46 @@ func (r *Response) populatePageValues(v interface{}) {
 		r.Count = value.Count
 		r.PageSize = value.PageSize
 		r.Offset = value.Offset
+	case *SearchResultProject:
+		r.Total = value.Total
+		r.Count = value.Count
+		r.PageSize = value.PageSize
+		r.Offset = value.Offset
+	case *SearchResultStatus:
+		r.Total = value.Total
+		r.Count = value.Count
+		r.PageSize = value.PageSize
+		r.Offset = value.Offset
+	case *SearchResultTimeEntry:
+		r.Total = value.Total
+		r.Count = value.Count
+		r.PageSize = value.PageSize
+		r.Offset = value.Offset
+	case *SearchResultRelation:
+		r.Total = value.Total
+		r.Count = value.Count
+		r.PageSize = value.PageSize
+		r.Offset = value.Offset
+	case *SearchResultVersion:
+		r.Total = value.Total
+		r.Count = value.Count
+		r.PageSize = value.PageSize
+		r.Offset = value.Offset
+	case *SearchResultMembership:
+		r.Total = value.Total
+		r.Count = value.Count
+		r.PageSize = value.PageSize
+		r.Offset = value.Offset
+	case *SearchResultPrincipal:
+		r.Total = value.Total
+		r.Count = value.Count
+		r.PageSize = value.PageSize
+		r.Offset = value.Offset
+	case *SearchResultGroup:
+		r.Total = value.Total
+		r.Count = value.Count
+		r.PageSize = value.PageSize
+		r.Offset = value.Offset
 	}
 }
 
@@ -359,6 +522,304 @@ func (t *BasicAuthTransport) transport() http.RoundTripper {
 	return http.DefaultTransport
 }
 
+// apiKeyUsername is the literal user name OpenProject expects along with an API key
+const apiKeyUsername = "apikey"
+
+// APIKeyAuthTransport is an http.RoundTripper that authenticates all requests
+// using an OpenProject API key (personal access token).
+// OpenProject expects it as HTTP Basic Authentication with the literal user "apikey"
+// and the API key as password.
+type APIKeyAuthTransport struct {
+	APIKey string
+
+	// Transport is the underlying HTTP transport to use when making requests.
+	// It will default to http.DefaultTransport if nil.
+	Transport http.RoundTripper
+}
+
+// RoundTrip implements the RoundTripper interface.  We just add the
+// API key as basic auth and return the RoundTripper for this transport type.
+func (t *APIKeyAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req2 := cloneRequest(req) // per RoundTripper contract
+	req2.SetBasicAuth(apiKeyUsername, t.APIKey)
+	return t.transport().RoundTrip(req2)
+}
+
+// Client returns an *http.Client that makes requests that are authenticated
+// using the OpenProject API key.
+func (t *APIKeyAuthTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// Transport
+func (t *APIKeyAuthTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
+
+// oauth2ExpiryDelta is how long before its expiry a token is considered expired,
+// so that it is not rejected while the request is in flight
+const oauth2ExpiryDelta = 10 * time.Second
+
+// OAuth2Token is an access token issued by the OpenProject OAuth token endpoint
+type OAuth2Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	CreatedAt    int64  `json:"created_at,omitempty"`
+
+	// Expiry is the moment the access token expires. Zero means it does not expire.
+	Expiry time.Time `json:"-"`
+}
+
+// Valid reports whether the token can still be used
+func (t *OAuth2Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(oauth2ExpiryDelta).Before(t.Expiry)
+}
+
+// OAuth2Transport is an http.RoundTripper that authenticates all requests
+// with an OAuth2 bearer token of an OpenProject OAuth application.
+// Tokens are obtained from TokenURL using the refresh token grant when RefreshToken is set
+// (e.g. stored after ExchangeCode) and the client credentials grant otherwise.
+// They are cached until they expire and refreshed transparently.
+// A request rejected with 401 Unauthorized is retried once with a fresh token.
+type OAuth2Transport struct {
+	// TokenURL is the OpenProject token endpoint, like https://openproject.example.com/oauth/token
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+
+	// Scope requested for the tokens. It will default to "api_v3" if empty.
+	Scope string
+
+	// RefreshToken is used to obtain new tokens when set. It is updated whenever OpenProject rotates it.
+	// If OpenProject rejects it, e.g. because it has been revoked, every request fails with the token endpoint error:
+	// there is no fallback to the client credentials grant. A new token must be set with SetToken or ExchangeCode.
+	RefreshToken string
+
+	// OnTokenRefresh is called with a copy of every new token, e.g. to persist the rotated refresh token.
+	// It is called without holding the transport lock, so it may use the transport, possibly concurrently.
+	OnTokenRefresh func(token *OAuth2Token)
+
+	// Transport is the underlying HTTP transport to use when making requests.
+	// It will default to http.DefaultTransport if nil.
+	Transport http.RoundTripper
+
+	mu    sync.Mutex
+	token *OAuth2Token
+	// session changes whenever a token of a possibly different user is set, refreshing tokens keeps it
+	session uint64
+}
+
+// RoundTrip implements the RoundTripper interface.  We add the bearer token
+// and retry once with a fresh token if the server rejects it.
+func (t *OAuth2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.TokenWithContext(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	req2 := cloneRequest(req) // per RoundTripper contract
+	req2.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	resp, err := t.transport().RoundTrip(req2)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	// The body has already been consumed and can not be sent again
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	t.invalidate(token)
+	token, err = t.TokenWithContext(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	req3 := cloneRequest(req)
+	if req.GetBody != nil {
+		req3.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+	req3.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	return t.transport().RoundTrip(req3)
+}
+
+// Client returns an *http.Client that makes requests that are authenticated
+// using OAuth2 bearer tokens.
+func (t *OAuth2Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// TokenWithContext returns the cached token or obtains a new one if it is missing or expired.
+func (t *OAuth2Transport) TokenWithContext(ctx context.Context) (*OAuth2Token, error) {
+	t.mu.Lock()
+	if t.token.Valid() {
+		token := t.token
+		t.mu.Unlock()
+		return token, nil
+	}
+
+	params := url.Values{}
+	if t.RefreshToken != "" {
+		params.Set("grant_type", "refresh_token")
+		params.Set("refresh_token", t.RefreshToken)
+	} else {
+		params.Set("grant_type", "client_credentials")
+		params.Set("scope", t.scope())
+	}
+	token, err := t.requestToken(ctx, params)
+	t.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	t.notifyRefresh(token)
+	return token, nil
+}
+
+// Token wraps TokenWithContext using the background context.
+func (t *OAuth2Transport) Token() (*OAuth2Token, error) {
+	return t.TokenWithContext(context.Background())
+}
+
+// SetToken stores a token obtained elsewhere, it will be used until it expires.
+func (t *OAuth2Transport) SetToken(token *OAuth2Token) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.token = token
+	t.session++
+	if token != nil && token.RefreshToken != "" {
+		t.RefreshToken = token.RefreshToken
+	}
+}
+
+// ExchangeCodeWithContext obtains a token using the authorization code grant.
+// code is the authorization code OpenProject sent to redirectURI after the user authorized the application.
+// The refresh token received is kept in RefreshToken for later renewals.
+func (t *OAuth2Transport) ExchangeCodeWithContext(ctx context.Context, code string, redirectURI string) (*OAuth2Token, error) {
+	params := url.Values{}
+	params.Set("grant_type", "authorization_code")
+	params.Set("code", code)
+	params.Set("redirect_uri", redirectURI)
+
+	t.mu.Lock()
+	token, err := t.requestToken(ctx, params)
+	if err == nil {
+		t.session++
+	}
+	t.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	t.notifyRefresh(token)
+	return token, nil
+}
+
+// ExchangeCode wraps ExchangeCodeWithContext using the background context.
+func (t *OAuth2Transport) ExchangeCode(code string, redirectURI string) (*OAuth2Token, error) {
+	return t.ExchangeCodeWithContext(context.Background(), code, redirectURI)
+}
+
+// requestToken sends a token request to TokenURL and caches the result. t.mu must be held.
+func (t *OAuth2Transport) requestToken(ctx context.Context, params url.Values) (*OAuth2Token, error) {
+	params.Set("client_id", t.ClientID)
+	if t.ClientSecret != "" {
+		params.Set("client_secret", t.ClientSecret)
+	}
+
+	req, err := newRequestWithContext(ctx, "POST", t.TokenURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := t.transport().RoundTrip(req)
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: could not read token response: %w", err)
+	}
+	if c := resp.StatusCode; c < 200 || c > 299 {
+		return nil, fmt.Errorf("oauth2: token request failed. Status code: %d: %s", resp.StatusCode, string(data))
+	}
+
+	token := new(OAuth2Token)
+	err = json.Unmarshal(data, token)
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: could not unmarshall the token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("oauth2: token response does not contain an access token")
+	}
+	if token.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	if token.RefreshToken != "" {
+		t.RefreshToken = token.RefreshToken
+	}
+
+	t.token = token
+	return token, nil
+}
+
+// notifyRefresh calls OnTokenRefresh with a copy of token. t.mu must not be held.
+func (t *OAuth2Transport) notifyRefresh(token *OAuth2Token) {
+	if t.OnTokenRefresh != nil {
+		refreshed := *token
+		t.OnTokenRefresh(&refreshed)
+	}
+}
+
+// currentSession returns the session of the current token, see OAuth2Transport.session
+func (t *OAuth2Transport) currentSession() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.session
+}
+
+// invalidate drops the cached token unless it has already been replaced
+func (t *OAuth2Transport) invalidate(token *OAuth2Token) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == token {
+		t.token = nil
+	}
+}
+
+// scope returns the requested scope
+func (t *OAuth2Transport) scope() string {
+	if t.Scope != "" {
+		return t.Scope
+	}
+	return "api_v3"
+}
+
+// Transport
+func (t *OAuth2Transport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
+
 // cloneRequest returns a clone of the provided *http.Request.
 // The clone is a shallow copy of the struct and its Header map.
 func cloneRequest(r *http.Request) *http.Request {
@@ -404,6 +865,30 @@ func getObjectAndClient(inputObj interface{}) (client *Client, resultObj interfa
 	case *ActivitiesService:
 		client = c.client
 		resultObj = new(Activity)
+	case *TimeEntryService:
+		client = c.client
+		resultObj = new(TimeEntry)
+	case *RelationService:
+		client = c.client
+		resultObj = new(Relation)
+	case *VersionService:
+		client = c.client
+		resultObj = new(Version)
+	case *TypeService:
+		client = c.client
+		resultObj = new(Type)
+	case *PriorityService:
+		client = c.client
+		resultObj = new(Priority)
+	case *MembershipService:
+		client = c.client
+		resultObj = new(Membership)
+	case *RoleService:
+		client = c.client
+		resultObj = new(Role)
+	case *GroupService:
+		client = c.client
+		resultObj = new(Group)
 	}
 
 	return client, resultObj
@@ -439,6 +924,24 @@ func getObjectListAndClient(inputObj interface{}) (client *Client, resultObjList
 	case *ActivitiesService:
 		client = c.client
 		resultObjList = new(Activities)
+	case *TimeEntryService:
+		client = c.client
+		resultObjList = new(SearchResultTimeEntry)
+	case *RelationService:
+		client = c.client
+		resultObjList = new(SearchResultRelation)
+	case *VersionService:
+		client = c.client
+		resultObjList = new(SearchResultVersion)
+	case *MembershipService:
+		client = c.client
+		resultObjList = new(SearchResultMembership)
+	case *PrincipalService:
+		client = c.client
+		resultObjList = new(SearchResultPrincipal)
+	case *GroupService:
+		client = c.client
+		resultObjList = new(SearchResultGroup)
 	}
 
 	return client, resultObjList
@@ -482,7 +985,10 @@ func GetListWithContext(ctx context.Context, objService interface{}, apiEndPoint
 	values.Add(kPageSize, strconv.Itoa(pageSize))
 
 	if options != nil {
-		values = options.prepareFilters(values)
+		values, err = options.prepareFilters(values)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 	req.URL.RawQuery = values.Encode()
 
@@ -492,6 +998,17 @@ func GetListWithContext(ctx context.Context, objService interface{}, apiEndPoint
 		return nil, resp, oerr
 	}
 
+	// Paging values missing from the body (not selected) are the requested ones
+	if page, ok := resultObjList.(interface{ paginationParam() *PaginationParam }); ok {
+		param := page.paginationParam()
+		if param.PageSize == 0 {
+			param.PageSize, resp.PageSize = pageSize, pageSize
+		}
+		if param.Offset == 0 {
+			param.Offset, resp.Offset = offset, offset
+		}
+	}
+
 	return resultObjList, resp, nil
 }
 
@@ -521,6 +1038,23 @@ func CreateWithContext(ctx context.Context, object interface{}, objService inter
 	return resultObj, resp, nil
 }
 
+// UpdateWithContext (generic) updates an instance of an object (HTTP PATCH verb)
+// Return the instance of the object rendered into proper struct as interface{} to be cast in the caller
+func UpdateWithContext(ctx context.Context, object interface{}, objService interface{}, apiEndPoint string) (interface{}, *Response, error) {
+	client, resultObj := getObjectAndClient(objService)
+	apiEndPoint = strings.TrimRight(apiEndPoint, "/")
+	req, err := client.NewRequestWithContext(ctx, "PATCH", apiEndPoint, object)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := client.Do(req, resultObj)
+	if err != nil {
+		return nil, resp, NewOpenProjectError(resp, err)
+	}
+	return resultObj, resp, nil
+}
+
 // DeleteWithContext (generic) retrieves object (HTTP DELETE verb)
 // obj can be any main object (attachment, user, project, work-package, etc...)
 func DeleteWithContext(ctx context.Context, objService interface{}, apiEndPoint string) (*Response, error) {
@@ -532,5 +1066,13 @@ func DeleteWithContext(ctx context.Context, objService interface{}, apiEndPoint
 	}
 
 	resp, err := client.Do(req, nil)
+	if resp != nil {
+		resp.Body.Close()
+	}
 	return resp, err
 }
+
+// Ptr returns a pointer to v, to fill the optional fields of patches, e.g. &QueryPatch{Public: Ptr(false)}
+func Ptr[T any](v T) *T {
+	return &v
+}
@@ -410,3 +410,235 @@ func TestBasicAuthTransport_transport(t *testing.T) {
 		t.Errorf("Expected custom transport to be used.")
 	}
 }
+
+func TestAPIKeyAuthTransport(t *testing.T) {
+	setup()
+	defer teardown()
+
+	apiKey := "secret-token"
+
+	testMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		u, p, ok := r.BasicAuth()
+		if !ok {
+			t.Errorf("request does not contain basic auth credentials")
+		}
+		if u != "apikey" {
+			t.Errorf("request contained basic auth username %q, want %q", u, "apikey")
+		}
+		if p != apiKey {
+			t.Errorf("request contained basic auth password %q, want %q", p, apiKey)
+		}
+	})
+
+	apiKeyClient, _ := NewClientWithAPIKey(testServer.URL, apiKey)
+	req, _ := apiKeyClient.NewRequest("GET", ".", nil)
+	apiKeyClient.Do(req, nil)
+}
+
+func TestAPIKeyAuthTransport_transport(t *testing.T) {
+	// default transport
+	tp := &APIKeyAuthTransport{}
+	if tp.transport() != http.DefaultTransport {
+		t.Errorf("Expected http.DefaultTransport to be used.")
+	}
+
+	// custom transport
+	tp = &APIKeyAuthTransport{
+		Transport: &http.Transport{},
+	}
+	if tp.transport() == http.DefaultTransport {
+		t.Errorf("Expected custom transport to be used.")
+	}
+}
+
+func TestOAuth2Transport_ClientCredentials(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tokenRequests := 0
+	testMux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		tokenRequests++
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Error parsing token request: %s", err)
+		}
+		if got := r.PostForm.Get("grant_type"); got != "client_credentials" {
+			t.Errorf("Expected grant_type client_credentials. Got %s", got)
+		}
+		if got := r.PostForm.Get("client_id"); got != "client-id" {
+			t.Errorf("Expected client_id client-id. Got %s", got)
+		}
+		if got := r.PostForm.Get("scope"); got != "api_v3" {
+			t.Errorf("Expected scope api_v3. Got %s", got)
+		}
+		fmt.Fprint(w, `{"access_token":"token-1","token_type":"Bearer","expires_in":7200,"scope":"api_v3"}`)
+	})
+	testMux.HandleFunc("/api/v3/", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token-1" {
+			t.Errorf("Expected bearer token. Got %s", got)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	tp := &OAuth2Transport{
+		TokenURL:     testServer.URL + "/oauth/token",
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+	}
+	c, _ := NewClient(tp.Client(), testServer.URL)
+	for i := 0; i < 2; i++ {
+		req, _ := c.NewRequest("GET", "api/v3/", nil)
+		if _, err := c.Do(req, nil); err != nil {
+			t.Errorf("Error given: %s", err)
+		}
+	}
+
+	if tokenRequests != 1 {
+		t.Errorf("Expected the token to be cached. Got %d token requests", tokenRequests)
+	}
+}
+
+func TestOAuth2Transport_RefreshOn401(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tokenRequests := 0
+	testMux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		r.ParseForm()
+		if got := r.PostForm.Get("grant_type"); got != "refresh_token" {
+			t.Errorf("Expected grant_type refresh_token. Got %s", got)
+		}
+		if got, want := r.PostForm.Get("refresh_token"), fmt.Sprintf("refresh-%d", tokenRequests); got != want {
+			t.Errorf("Expected refresh_token %s. Got %s", want, got)
+		}
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":7200,"refresh_token":"refresh-%d"}`, tokenRequests, tokenRequests+1)
+	})
+	testMux.HandleFunc("/api/v3/work_packages/1", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"lockVersion":0,"subject":"s"}`+"\n" {
+			t.Errorf("Expected the body to be sent on every attempt. Got %q", string(body))
+		}
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	var refreshed []string
+	tp := &OAuth2Transport{
+		TokenURL:     testServer.URL + "/oauth/token",
+		ClientID:     "client-id",
+		RefreshToken: "refresh-1",
+		OnTokenRefresh: func(token *OAuth2Token) {
+			refreshed = append(refreshed, token.RefreshToken)
+		},
+	}
+	c, _ := NewClient(tp.Client(), testServer.URL)
+	wp, _, err := c.WorkPackage.Update("1", &WorkPackagePatch{Subject: Ptr("s")})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if wp.ID != 1 {
+		t.Errorf("Expected work-package 1. Got %d", wp.ID)
+	}
+	if tokenRequests != 2 {
+		t.Errorf("Expected 2 token requests. Got %d", tokenRequests)
+	}
+	if !reflect.DeepEqual(refreshed, []string{"refresh-2", "refresh-3"}) || tp.RefreshToken != "refresh-3" {
+		t.Errorf("Expected rotated refresh tokens to be kept. Got %v and %s", refreshed, tp.RefreshToken)
+	}
+}
+
+func TestOAuth2Transport_ExchangeCode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if got := r.PostForm.Get("grant_type"); got != "authorization_code" {
+			t.Errorf("Expected grant_type authorization_code. Got %s", got)
+		}
+		if got := r.PostForm.Get("code"); got != "the-code" {
+			t.Errorf("Expected code the-code. Got %s", got)
+		}
+		if got := r.PostForm.Get("redirect_uri"); got != "urn:ietf:wg:oauth:2.0:oob" {
+			t.Errorf("Expected redirect_uri. Got %s", got)
+		}
+		fmt.Fprint(w, `{"access_token":"token-1","token_type":"Bearer","expires_in":7200,"refresh_token":"refresh-1"}`)
+	})
+
+	tp := &OAuth2Transport{TokenURL: testServer.URL + "/oauth/token", ClientID: "client-id"}
+	token, err := tp.ExchangeCode("the-code", "urn:ietf:wg:oauth:2.0:oob")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !token.Valid() {
+		t.Error("Expected a valid token")
+	}
+	if tp.RefreshToken != "refresh-1" {
+		t.Errorf("Expected refresh token to be stored. Got %s", tp.RefreshToken)
+	}
+}
+
+func TestOAuth2Transport_OnTokenRefreshReentrant(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"token-1","token_type":"Bearer","expires_in":7200,"refresh_token":"refresh-2"}`)
+	})
+
+	tp := &OAuth2Transport{TokenURL: testServer.URL + "/oauth/token", ClientID: "client-id", RefreshToken: "refresh-1"}
+	tp.OnTokenRefresh = func(token *OAuth2Token) {
+		// The callback must be able to use the transport without deadlocking
+		if current, err := tp.Token(); err != nil || current.AccessToken != token.AccessToken {
+			t.Errorf("Expected the refreshed token. Got %+v, %v", current, err)
+		}
+		tp.SetToken(token)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := tp.Token(); err != nil {
+			t.Errorf("Error given: %s", err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnTokenRefresh deadlocked")
+	}
+}
+
+func TestOAuth2Transport_TokenError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":"invalid_client"}`)
+	})
+
+	tp := &OAuth2Transport{TokenURL: testServer.URL + "/oauth/token", ClientID: "client-id"}
+	c, _ := NewClient(tp.Client(), testServer.URL)
+	req, _ := c.NewRequest("GET", "api/v3/", nil)
+	_, err := c.Do(req, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid_client") {
+		t.Errorf("Expected token request error. Got %v", err)
+	}
+}
+
+func TestOAuth2Token_Valid(t *testing.T) {
+	var nilToken *OAuth2Token
+	if nilToken.Valid() {
+		t.Error("Expected nil token to be invalid")
+	}
+	if (&OAuth2Token{AccessToken: "t", Expiry: time.Now().Add(time.Second)}).Valid() {
+		t.Error("Expected token about to expire to be invalid")
+	}
+	if !(&OAuth2Token{AccessToken: "t"}).Valid() {
+		t.Error("Expected token without expiry to be valid")
+	}
+}
@@ -0,0 +1,160 @@
+// Package otelopenproject adapts the instrumentation hooks of the OpenProject client to OpenTelemetry.
+// It lives in its own module so the client does not depend on OpenTelemetry.
+// Usage case:
+//
+//	instrumentation, err := otelopenproject.New()
+//	client, err := openproj.NewClient(nil, "https://youropenproject.url", openproj.WithInstrumentation(instrumentation))
+package otelopenproject
+
+import (
+	"context"
+	"net/http"
+
+	openproj "github.com/manuelbcd/go-openproject"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the tracer and meter of this package
+const instrumentationName = "github.com/manuelbcd/go-openproject/otelopenproject"
+
+// Attributes specific to OpenProject requests
+const (
+	// ServiceKey is the name of the client service sending the request, e.g. WorkPackage
+	ServiceKey = attribute.Key("openproject.service")
+	// EndpointKey is the templated endpoint of the request, e.g. api/v3/work_packages/{id}
+	EndpointKey = attribute.Key("openproject.endpoint")
+)
+
+// config holds the options of New
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option configures the Instrumentation returned by New
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider creating spans. It will default to the global one.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the meter provider recording metrics. It will default to the global one.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// Instrumentation creates a client span and records metrics for every request sent by the client.
+// Metrics are labelled with the templated endpoint, never the raw URL, to keep their cardinality low.
+type Instrumentation struct {
+	tracer       trace.Tracer
+	duration     metric.Float64Histogram
+	requestSize  metric.Int64Histogram
+	responseSize metric.Int64Histogram
+	retries      metric.Int64Counter
+}
+
+// New returns an Instrumentation to give to openproj.WithInstrumentation
+func New(options ...Option) (*Instrumentation, error) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(instrumentationName)
+	i := &Instrumentation{tracer: cfg.tracerProvider.Tracer(instrumentationName)}
+	var err error
+	i.duration, err = meter.Float64Histogram("http.client.request.duration",
+		metric.WithUnit("s"), metric.WithDescription("Duration of OpenProject API requests"))
+	if err != nil {
+		return nil, err
+	}
+	i.requestSize, err = meter.Int64Histogram("http.client.request.body.size",
+		metric.WithUnit("By"), metric.WithDescription("Size of OpenProject API request bodies"))
+	if err != nil {
+		return nil, err
+	}
+	i.responseSize, err = meter.Int64Histogram("http.client.response.body.size",
+		metric.WithUnit("By"), metric.WithDescription("Size of OpenProject API response bodies"))
+	if err != nil {
+		return nil, err
+	}
+	i.retries, err = meter.Int64Counter("openproject.client.retries",
+		metric.WithUnit("{retry}"), metric.WithDescription("Retries of OpenProject API requests"))
+	if err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// StartRequest starts a client span named after the method and the templated endpoint
+func (i *Instrumentation) StartRequest(ctx context.Context, info openproj.RequestInfo) context.Context {
+	ctx, _ = i.tracer.Start(ctx, info.Method+" "+info.Endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(info.Method),
+			semconv.URLFull(info.URL),
+			ServiceKey.String(info.Service),
+			EndpointKey.String(info.Endpoint),
+		),
+	)
+	return ctx
+}
+
+// EndRequest ends the span started by StartRequest and records the request metrics
+func (i *Instrumentation) EndRequest(ctx context.Context, info openproj.RequestInfo, result openproj.RequestResult) {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(info.Method),
+		ServiceKey.String(info.Service),
+		EndpointKey.String(info.Endpoint),
+	}
+	if result.StatusCode != 0 {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(result.StatusCode))
+	}
+	if result.StatusCode >= http.StatusBadRequest {
+		attrs = append(attrs, semconv.ErrorTypeKey.String(http.StatusText(result.StatusCode)))
+	} else if result.Err != nil {
+		attrs = append(attrs, semconv.ErrorTypeOther)
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attrs...)
+	if result.Retries > 0 {
+		span.SetAttributes(semconv.HTTPRequestResendCount(result.Retries))
+	}
+	if result.RequestBytes > 0 {
+		span.SetAttributes(semconv.HTTPRequestBodySize(int(result.RequestBytes)))
+	}
+	if result.ResponseBytes > 0 {
+		span.SetAttributes(semconv.HTTPResponseBodySize(int(result.ResponseBytes)))
+	}
+	if result.Err != nil {
+		span.RecordError(result.Err)
+		span.SetStatus(codes.Error, result.Err.Error())
+	}
+	span.End()
+
+	set := metric.WithAttributes(attrs...)
+	i.duration.Record(ctx, result.Duration.Seconds(), set)
+	if result.RequestBytes > 0 {
+		i.requestSize.Record(ctx, result.RequestBytes, set)
+	}
+	if result.ResponseBytes >= 0 {
+		i.responseSize.Record(ctx, result.ResponseBytes, set)
+	}
+	if result.Retries > 0 {
+		i.retries.Add(ctx, int64(result.Retries), set)
+	}
+}
@@ -0,0 +1,94 @@
+package otelopenproject
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	openproj "github.com/manuelbcd/go-openproject"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInstrumentation(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/api/v3/work_packages/42", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_type":"WorkPackage","id":42,"subject":"Test"}`)
+	})
+	mux.HandleFunc("/api/v3/work_packages/43", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	instrumentation, err := New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	client, _ := openproj.NewClient(nil, server.URL, openproj.WithInstrumentation(instrumentation))
+	if _, _, err := client.WorkPackage.Get("42"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := client.WorkPackage.Get("43"); err == nil {
+		t.Fatal("Expected error, but no error given")
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("Expected 2 spans. Got %d", len(ended))
+	}
+	if name := ended[0].Name(); name != "GET api/v3/work_packages/{id}" {
+		t.Errorf("Unexpected span name %s", name)
+	}
+	if !hasAttribute(ended[0].Attributes(), ServiceKey.String("WorkPackage")) {
+		t.Errorf("Expected the service attribute. Got %v", ended[0].Attributes())
+	}
+	if ended[1].Status().Code != codes.Error {
+		t.Errorf("Expected the failed request span to have an error status. Got %v", ended[1].Status())
+	}
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != "http.client.request.duration" {
+				continue
+			}
+			histogram := m.Data.(metricdata.Histogram[float64])
+			if len(histogram.DataPoints) != 2 {
+				t.Errorf("Expected a data point per status code. Got %d", len(histogram.DataPoints))
+			}
+			for _, point := range histogram.DataPoints {
+				if !point.Attributes.HasValue(EndpointKey) {
+					t.Errorf("Expected the endpoint attribute. Got %v", point.Attributes)
+				}
+			}
+			return
+		}
+	}
+	t.Error("Expected the request duration metric to be recorded")
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == want {
+			return true
+		}
+	}
+	return false
+}
@@ -1,93 +1,274 @@
 package openproject
 
 import (
-	"fmt"
-	rbt "github.com/emirpasic/gods/trees/redblacktree"
+	"context"
 )
 
-type saveBox struct {
-	Index int
-	Res   interface{}
+// Default values used when walking paginated collections
+const (
+	defaultPagerPageSize        = 100
+	defaultAutoPageTurnPageSize = 10
+	autoPageTurnConcurrency     = 4
+)
+
+// PageFetcher fetches a single page of a collection.
+// offset is the page number, starting at 1, as OpenProject expects it.
+// Services GetListWithContext methods without filters already match it, e.g. StatusService.GetListWithContext
+type PageFetcher[P any] func(ctx context.Context, offset int, pageSize int) (P, *Response, error)
+
+// Page is implemented by the collection responses whose elements can be iterated
+type Page[T any] interface {
+	IPaginationResponse
+	Elements() []T
+}
+
+// WithFilter adapts a GetListWithContext method accepting FilterOptions to a PageFetcher
+// Usage case:
+//
+//	fetch := WithFilter(filters, client.WorkPackage.GetListWithContext)
+func WithFilter[P any](options *FilterOptions,
+	fetch func(context.Context, *FilterOptions, int, int) (P, *Response, error)) PageFetcher[P] {
+	return func(ctx context.Context, offset int, pageSize int) (P, *Response, error) {
+		return fetch(ctx, options, offset, pageSize)
+	}
+}
+
+// Pager walks every element of a paginated collection, fetching pages lazily.
+// Only Concurrency pages are kept in memory at any time, so it is suitable for very large collections.
+// Usage case:
+//
+//	pager := NewPager[WorkPackage](WithFilter(nil, client.WorkPackage.GetListWithContext))
+//	it := pager.Iterate(ctx)
+//	defer it.Close()
+//	for it.Next() {
+//		wp := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Pager[T any] struct {
+	// PageSize is the number of elements requested per page. It will default to 100 if 0.
+	PageSize int
+
+	// Concurrency is the maximum number of pages fetched ahead in parallel.
+	// It will default to 1 (pages are fetched one after the other when needed) if 0.
+	Concurrency int
+
+	fetch func(ctx context.Context, offset int, pageSize int) ([]T, int, error)
+}
+
+// NewPager returns a Pager over the elements of the pages returned by fetch.
+// The element type must be given explicitly, the page type is inferred:
+//
+//	NewPager[Status](client.Status.GetListWithContext)
+func NewPager[T any, P Page[T]](fetch PageFetcher[P]) *Pager[T] {
+	return &Pager[T]{
+		fetch: func(ctx context.Context, offset int, pageSize int) ([]T, int, error) {
+			page, _, err := fetch(ctx, offset, pageSize)
+			if err != nil {
+				return nil, 0, err
+			}
+			return page.Elements(), page.TotalPage(), nil
+		},
+	}
+}
+
+// Iterate starts walking the collection. The first page is requested on the first call to Next.
+// The returned Iterator must be closed to release prefetching resources if it is not consumed completely.
+func (p *Pager[T]) Iterate(ctx context.Context) *Iterator[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Iterator[T]{pager: p, ctx: ctx, cancel: cancel}
+}
+
+// pageSize returns the configured page size
+func (p *Pager[T]) pageSize() int {
+	if p.PageSize > 0 {
+		return p.PageSize
+	}
+	return defaultPagerPageSize
+}
+
+// concurrency returns the configured number of pages fetched ahead
+func (p *Pager[T]) concurrency() int {
+	if p.Concurrency > 0 {
+		return p.Concurrency
+	}
+	return 1
+}
+
+// Iterator streams the elements of a collection walked by a Pager.
+// It is not safe for concurrent use.
+type Iterator[T any] struct {
+	pager  *Pager[T]
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	started bool
+	pages   <-chan chan pageResult[[]T]
+	current []T
+	index   int
+	value   T
+	err     error
+}
+
+// Next advances to the next element, fetching the next page if needed.
+// It returns false when the collection is exhausted or an error occurred, check Err to distinguish both.
+func (it *Iterator[T]) Next() bool {
+	for it.index >= len(it.current) {
+		if it.err != nil || !it.nextPage() {
+			var zero T
+			it.value = zero
+			return false
+		}
+	}
+	it.value = it.current[it.index]
+	it.index++
+	return true
+}
+
+// Value returns the current element
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the first error found while fetching pages, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops prefetching pages. It is safe to call it several times.
+func (it *Iterator[T]) Close() {
+	it.cancel()
+}
+
+// nextPage loads the next page into current. It returns false if there are no more pages.
+func (it *Iterator[T]) nextPage() bool {
+	it.current, it.index = nil, 0
+	if !it.started {
+		it.started = true
+		elements, totalPage, err := it.pager.fetch(it.ctx, 1, it.pager.pageSize())
+		if err != nil {
+			it.fail(err)
+			return false
+		}
+		it.current = elements
+		if totalPage > 1 && len(elements) > 0 {
+			it.pages = fetchPages(it.ctx, 2, totalPage, it.pager.concurrency(),
+				func(ctx context.Context, offset int) ([]T, error) {
+					elements, _, err := it.pager.fetch(ctx, offset, it.pager.pageSize())
+					return elements, err
+				})
+		}
+		return true
+	}
+	if it.pages == nil {
+		return false
+	}
+
+	var page chan pageResult[[]T]
+	var ok bool
+	select {
+	case page, ok = <-it.pages:
+	case <-it.ctx.Done():
+		it.fail(it.ctx.Err())
+		return false
+	}
+	if !ok {
+		it.pages = nil
+		it.cancel()
+		return false
+	}
+	select {
+	case res := <-page:
+		if res.err != nil {
+			it.fail(res.err)
+			return false
+		}
+		it.current = res.page
+		return true
+	case <-it.ctx.Done():
+		it.fail(it.ctx.Err())
+		return false
+	}
+}
+
+// fail records the first error and stops prefetching
+func (it *Iterator[T]) fail(err error) {
+	if it.err == nil {
+		it.err = err
+	}
+	it.pages = nil
+	it.cancel()
+}
+
+// pageResult is the outcome of a single page request
+type pageResult[P any] struct {
+	page P
+	err  error
+}
+
+// fetchPages requests pages from first to last with at most concurrency requests in flight.
+// Results are delivered in page order: every element of the returned channel is a channel receiving that page.
+// It stops as soon as ctx is cancelled.
+func fetchPages[P any](ctx context.Context, first int, last int, concurrency int,
+	fetch func(context.Context, int) (P, error)) <-chan chan pageResult[P] {
+	// The page being awaited by the consumer plus the queued ones make up the concurrency limit
+	queue := make(chan chan pageResult[P], concurrency-1)
+	go func() {
+		defer close(queue)
+		for offset := first; offset <= last; offset++ {
+			res := make(chan pageResult[P], 1)
+			select {
+			case queue <- res:
+			case <-ctx.Done():
+				return
+			}
+			go func(offset int) {
+				page, err := fetch(ctx, offset)
+				res <- pageResult[P]{page: page, err: err}
+			}(offset)
+		}
+	}()
+	return queue
 }
 
 // AutoPageTurn auto page turn
 // @notice Use careful when dealing large amounts of data because it will set all objects in memory.
+// Use a Pager to stream the elements instead.
 // Usage case:
 //
 //	users, err := AutoPageTurn(nil, 10, testClient.User.GetList)
 func AutoPageTurn[T IPaginationResponse](filter *FilterOptions, pageSize int,
 	fetch func(*FilterOptions, int, int) (T, *Response, error)) (T, error) {
-	var res T
-	offset := 1
 	if pageSize == 0 {
-		pageSize = 10
+		pageSize = defaultAutoPageTurnPageSize
 	}
 	// First request get total count
-	var err error
-	res, _, err = fetch(filter, offset, pageSize)
+	res, _, err := fetch(filter, 1, pageSize)
 	if err != nil {
 		return res, err
 	}
-	if res.TotalPage() < 2 {
+	totalPage := res.TotalPage()
+	if totalPage < 2 {
 		// less 2 page, return directly
 		return res, nil
 	}
-	totalPage := res.TotalPage()
-
-	box := make(chan saveBox)
-	// use more goroutine for speed up
-	for i := offset; i <= totalPage; i++ {
-		go request(box, filter, pageSize, i, fetch)
-	}
 
-	// Sort by red-black tree
-	t := addResToTree(box, res.TotalPage())
-	var tmpRes T
-	for _, key := range t.Keys() {
-		b, found := t.Get(key)
-		if found {
-			bx := b.(T)
-			if isInterfaceZero(tmpRes) {
-				tmpRes = bx
-			} else {
-				tmpRes.ConcatEmbed(bx)
-			}
+	// use more goroutine for speed up, pages are concatenated in order
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pages := fetchPages(ctx, 2, totalPage, autoPageTurnConcurrency, func(ctx context.Context, offset int) (T, error) {
+		pageRes, _, err := fetch(filter, offset, pageSize)
+		return pageRes, err
+	})
+	for page := range pages {
+		pageRes := <-page
+		if pageRes.err != nil {
+			var zero T
+			return zero, pageRes.err
 		}
+		res.ConcatEmbed(pageRes.page)
 	}
 
-	return tmpRes, nil
-}
-
-func addResToTree(collection <-chan saveBox, size int) *rbt.Tree {
-	t := rbt.NewWithIntComparator()
-	for i := 0; i < size; i++ {
-		res := <-collection
-		fmt.Printf("get res %+v", res)
-		t.Put(res.Index, res.Res)
-	}
-	return t
-}
-
-func request[T IPaginationResponse](ch chan<- saveBox, filter *FilterOptions, pageSize int, idx int, fetch func(*FilterOptions, int, int) (T, *Response, error)) {
-	pageRes, _, err := fetch(filter, idx, pageSize)
-	if err != nil {
-		return
-	}
-	ch <- saveBox{Index: idx, Res: pageRes}
-}
-
-func isInterfaceZero[T any](val T) bool {
-	switch v := any(val).(type) {
-	case *SearchResultProject:
-		return v == nil
-	case *SearchResultQuery:
-		return v == nil
-	case *SearchResultStatus:
-		return v == nil
-	case *SearchResultUser:
-		return v == nil
-	case *SearchResultWP:
-		return v == nil
-	}
-	return false
+	return res, nil
 }
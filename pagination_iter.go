@@ -0,0 +1,33 @@
+//go:build go1.23
+
+package openproject
+
+import (
+	"context"
+	"iter"
+)
+
+// All returns the elements of the collection as an iterator to be used with range.
+// The first error found stops the iteration and is yielded along with the zero value.
+// Usage case:
+//
+//	for wp, err := range pager.All(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//	}
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		it := p.Iterate(ctx)
+		defer it.Close()
+		for it.Next() {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
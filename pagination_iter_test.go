@@ -0,0 +1,35 @@
+//go:build go1.23
+
+package openproject
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPager_All(t *testing.T) {
+	setup()
+	defer teardown()
+	handleUsersPagination(t, 0, nil)
+
+	pager := NewPager[User](WithFilter(nil, testClient.User.GetListWithContext))
+	pager.PageSize = 10
+	pager.Concurrency = 2
+
+	count := 0
+	for user, err := range pager.All(context.Background()) {
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		count++
+		if user.ID != count {
+			t.Fatalf("Expected user %d, got %d", count, user.ID)
+		}
+		if count == 15 {
+			break
+		}
+	}
+	if count != 15 {
+		t.Errorf("Expected to stop after 15 users, got %d", count)
+	}
+}
@@ -1,11 +1,15 @@
 package openproject
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestAutoPageTurn(t *testing.T) {
@@ -57,3 +61,129 @@ func TestAutoPageTurn(t *testing.T) {
 		t.Errorf("Expected total in json %d is not equal the length of elements %d.", totalInJson, lenOfElement)
 	}
 }
+
+// handleUsersPagination serves the users pagination mocks, failing on page failOffset if not 0
+func handleUsersPagination(t *testing.T, failOffset int, requests *int32) {
+	testMux.HandleFunc("/api/v3/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if requests != nil {
+			atomic.AddInt32(requests, 1)
+		}
+		offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+		if err != nil {
+			t.Errorf("Expected offset to be an integer, %s given", r.URL.Query().Get("offset"))
+		}
+		if offset == failOffset {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		raw, _ := os.ReadFile(fmt.Sprintf("./mocks/get/get-users-pagination-%d.json", offset-1))
+		fmt.Fprint(w, string(raw))
+	})
+}
+
+func TestAutoPageTurn_Error(t *testing.T) {
+	setup()
+	defer teardown()
+	handleUsersPagination(t, 3, nil)
+
+	done := make(chan error)
+	go func() {
+		_, err := AutoPageTurn(nil, 10, testClient.User.GetList)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Expected error, but no error given")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("AutoPageTurn did not return after a page request failed")
+	}
+}
+
+func TestPager_Iterate(t *testing.T) {
+	for _, concurrency := range []int{0, 1, 3} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			setup()
+			defer teardown()
+			var requests int32
+			handleUsersPagination(t, 0, &requests)
+
+			pager := NewPager[User](WithFilter(nil, testClient.User.GetListWithContext))
+			pager.PageSize = 10
+			pager.Concurrency = concurrency
+
+			it := pager.Iterate(context.Background())
+			defer it.Close()
+			ids := []int{}
+			for it.Next() {
+				ids = append(ids, it.Value().ID)
+			}
+			if err := it.Err(); err != nil {
+				t.Fatalf("Error given: %s", err)
+			}
+			if len(ids) != 25 {
+				t.Fatalf("Expected 25 users, got %d", len(ids))
+			}
+			for i, id := range ids {
+				if id != i+1 {
+					t.Fatalf("Expected users in order, got %v", ids)
+				}
+			}
+			if requests != 3 {
+				t.Errorf("Expected 3 page requests, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestPager_Iterate_Error(t *testing.T) {
+	setup()
+	defer teardown()
+	handleUsersPagination(t, 2, nil)
+
+	pager := NewPager[User](WithFilter(nil, testClient.User.GetListWithContext))
+	pager.PageSize = 10
+	pager.Concurrency = 2
+
+	it := pager.Iterate(context.Background())
+	defer it.Close()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 10 {
+		t.Errorf("Expected the 10 users of the first page, got %d", count)
+	}
+	var opErr *Error
+	if !errors.As(it.Err(), &opErr) || opErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected the error of the second page, got %v", it.Err())
+	}
+	if it.Next() {
+		t.Error("Expected Next to keep returning false after an error")
+	}
+}
+
+func TestPager_Iterate_ContextCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+	handleUsersPagination(t, 0, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pager := NewPager[User](WithFilter(nil, testClient.User.GetListWithContext))
+	pager.PageSize = 10
+
+	it := pager.Iterate(ctx)
+	defer it.Close()
+	if !it.Next() {
+		t.Fatalf("Expected a first user, got error %v", it.Err())
+	}
+	cancel()
+	for it.Next() {
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", it.Err())
+	}
+}
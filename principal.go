@@ -0,0 +1,117 @@
+package openproject
+
+import (
+	"context"
+	"math"
+)
+
+// PrincipalService searches users, groups and placeholder users together for the OpenProject instance / API.
+type PrincipalService struct {
+	client *Client
+}
+
+// PrincipalType is the kind of a principal
+type PrincipalType string
+
+// Constants to represent OpenProject principal types
+const (
+	PrincipalUser            PrincipalType = "User"
+	PrincipalGroup           PrincipalType = "Group"
+	PrincipalPlaceholderUser PrincipalType = "PlaceholderUser"
+)
+
+// Principal is the object representing OpenProject principals: users, groups or placeholder users.
+// Login, FirstName, LastName and Email are only set for users.
+type Principal struct {
+	Type      PrincipalType `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID        int           `json:"id,omitempty" structs:"id,omitempty"`
+	Name      string        `json:"name,omitempty" structs:"name,omitempty"`
+	Login     string        `json:"login,omitempty" structs:"login,omitempty"`
+	FirstName string        `json:"firstName,omitempty" structs:"firstName,omitempty"`
+	LastName  string        `json:"lastName,omitempty" structs:"lastName,omitempty"`
+	Email     string        `json:"email,omitempty" structs:"email,omitempty"`
+	Status    string        `json:"status,omitempty" structs:"status,omitempty"`
+	CreatedAt *Time         `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	UpdatedAt *Time         `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+	Links     struct {
+		Self        *OPGenericLink `json:"self,omitempty" structs:"self,omitempty"`
+		Memberships *OPGenericLink `json:"memberships,omitempty" structs:"memberships,omitempty"`
+	} `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// SearchResultPrincipal represent a list of principals
+type SearchResultPrincipal struct {
+	Embedded principalElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	PaginationParam
+}
+
+func (s *SearchResultPrincipal) TotalPage() int {
+	return int(math.Ceil(float64(s.Total) / float64(s.PageSize)))
+}
+
+func (s *SearchResultPrincipal) ConcatEmbed(principals interface{}) {
+	s.Embedded.Elements = append(s.Embedded.Elements, principals.(*SearchResultPrincipal).Embedded.Elements...)
+}
+
+// Elements returns the principals of the page
+func (s *SearchResultPrincipal) Elements() []Principal {
+	return s.Embedded.Elements
+}
+
+// principalElements array wraps elements within SearchResultPrincipal
+type principalElements struct {
+	Elements []Principal `json:"elements,omitempty" structs:"elements,omitempty"`
+}
+
+// FilterPrincipalType filters principals by type, e.g. FilterPrincipalType(PrincipalUser, PrincipalGroup)
+func FilterPrincipalType(types ...PrincipalType) Filter {
+	values := make([]interface{}, 0, len(types))
+	for _, principalType := range types {
+		values = append(values, string(principalType))
+	}
+	return NewFilter("type", Equal, values...)
+}
+
+// FilterMember filters the principals which are members of projects, e.g. FilterMember(3)
+func FilterMember(args ...interface{}) Filter {
+	return fieldFilter("member", args)
+}
+
+// FilterNameContains filters principals whose name, login or email contains text
+func FilterNameContains(text string) Filter {
+	return NewFilter("any_name_attribute", Like, text)
+}
+
+// GetListWithContext retrieves the principals visible to the user, which can be filtered by type, name or project
+func (s *PrincipalService) GetListWithContext(ctx context.Context, options *FilterOptions, offset int, pageSize int) (*SearchResultPrincipal, *Response, error) {
+	apiEndpoint := "api/v3/principals"
+	obj, resp, err := GetListWithContext(ctx, s, apiEndpoint, options, offset, pageSize)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*SearchResultPrincipal), resp, err
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *PrincipalService) GetList(options *FilterOptions, offset int, pageSize int) (*SearchResultPrincipal, *Response, error) {
+	return s.GetListWithContext(context.Background(), options, offset, pageSize)
+}
+
+// SearchWithContext retrieves the first page of principals whose name, login or email contains text.
+// Without types, users, groups and placeholder users are all searched.
+func (s *PrincipalService) SearchWithContext(ctx context.Context, text string, pageSize int, types ...PrincipalType) ([]Principal, *Response, error) {
+	options := NewFilterOptions(FilterNameContains(text))
+	if len(types) > 0 {
+		options.Add(FilterPrincipalType(types...))
+	}
+	result, resp, err := s.GetListWithContext(ctx, options, 1, pageSize)
+	if err != nil {
+		return nil, resp, err
+	}
+	return result.Elements(), resp, nil
+}
+
+// Search wraps SearchWithContext using the background context.
+func (s *PrincipalService) Search(text string, pageSize int, types ...PrincipalType) ([]Principal, *Response, error) {
+	return s.SearchWithContext(context.Background(), text, pageSize, types...)
+}
@@ -0,0 +1,33 @@
+package openproject
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestPrincipalService_Search(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/principals", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		want := `[{"any_name_attribute":{"operator":"~","values":["dev"]}},{"type":{"operator":"=","values":["User","Group"]}}]`
+		if got := r.URL.Query().Get("filters"); got != want {
+			t.Errorf("Expected filters %s. Got %s", want, got)
+		}
+		fmt.Fprint(w, `{"_type":"Collection","total":2,"count":2,"pageSize":20,"offset":1,"_embedded":{"elements":[
+			{"_type":"User","id":5,"name":"Jane Developer","login":"jdev","email":"jane@example.com"},
+			{"_type":"Group","id":7,"name":"Developers"}]}}`)
+	})
+
+	principals, resp, err := testClient.Principal.Search("dev", 20, PrincipalUser, PrincipalGroup)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(principals) != 2 || resp.Total != 2 {
+		t.Fatalf("Expected 2 principals. Got %d", len(principals))
+	}
+	if principals[0].Type != PrincipalUser || principals[0].Login != "jdev" || principals[1].Type != PrincipalGroup {
+		t.Errorf("Unexpected principals %+v", principals)
+	}
+}
@@ -0,0 +1,69 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+)
+
+// PriorityService handles work-package priorities for the OpenProject instance / API.
+type PriorityService struct {
+	client *Client
+}
+
+// Priority is the object representing OpenProject work-package priorities
+type Priority struct {
+	Type      string `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID        int    `json:"id,omitempty" structs:"id,omitempty"`
+	Name      string `json:"name,omitempty" structs:"name,omitempty"`
+	Color     string `json:"color,omitempty" structs:"color,omitempty"`
+	Position  int    `json:"position,omitempty" structs:"position,omitempty"`
+	IsDefault bool   `json:"isDefault,omitempty" structs:"isDefault,omitempty"`
+	IsActive  bool   `json:"isActive,omitempty" structs:"isActive,omitempty"`
+	Links     struct {
+		Self *OPGenericLink `json:"self,omitempty" structs:"self,omitempty"`
+	} `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// PriorityList is the list of priorities
+type PriorityList struct {
+	Embedded struct {
+		Elements []Priority `json:"elements,omitempty" structs:"elements,omitempty"`
+	} `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Total int `json:"total,omitempty" structs:"total,omitempty"`
+	Count int `json:"count,omitempty" structs:"count,omitempty"`
+}
+
+// Elements returns the priorities of the list
+func (l *PriorityList) Elements() []Priority {
+	return l.Embedded.Elements
+}
+
+// GetWithContext gets a priority from OpenProject using its ID
+func (s *PriorityService) GetWithContext(ctx context.Context, priorityID string) (*Priority, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/priorities/%s", priorityID)
+	obj, resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Priority), resp, err
+}
+
+// Get wraps GetWithContext using the background context.
+func (s *PriorityService) Get(priorityID string) (*Priority, *Response, error) {
+	return s.GetWithContext(context.Background(), priorityID)
+}
+
+// GetListWithContext retrieves every priority of the instance
+func (s *PriorityService) GetListWithContext(ctx context.Context) (*PriorityList, *Response, error) {
+	list := new(PriorityList)
+	resp, err := getCollection(ctx, s.client, "api/v3/priorities", list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return list, resp, nil
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *PriorityService) GetList() (*PriorityList, *Response, error) {
+	return s.GetListWithContext(context.Background())
+}
@@ -0,0 +1,47 @@
+package openproject
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestPriorityService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/priorities/9", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/api/v3/priorities/9")
+		fmt.Fprint(w, `{"_type":"Priority","id":9,"name":"High","isActive":true}`)
+	})
+
+	priority, _, err := testClient.Priority.Get("9")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if priority.Name != "High" {
+		t.Errorf("Expected priority High. Got %s", priority.Name)
+	}
+}
+
+func TestPriorityService_GetList(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-priorities.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/priorities", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(raw))
+	})
+
+	priorities, _, err := testClient.Priority.GetList()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(priorities.Elements()) != 3 || !priorities.Elements()[1].IsDefault {
+		t.Errorf("Unexpected priorities %+v", priorities.Elements())
+	}
+}
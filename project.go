@@ -27,6 +27,11 @@ func (s *SearchResultProject) ConcatEmbed(proj interface{}) {
 	s.Embedded.Elements = append(s.Embedded.Elements, proj.(*SearchResultProject).Embedded.Elements...)
 }
 
+// Elements returns the projects of the page
+func (s *SearchResultProject) Elements() []Project {
+	return s.Embedded.Elements
+}
+
 // ProjectElements represent elements within SearchResultProject
 type projectElements struct {
 	Elements []Project `json:"elements,omitempty" structs:"elements,omitempty"`
@@ -83,15 +88,15 @@ func (s *ProjectService) Get(projectID string) (*Project, *Response, error) {
 }
 
 // GetList wraps GetListWithContext using the background context.
-func (s *ProjectService) GetList(offset int, pageSize int) (*SearchResultProject, *Response, error) {
-	return s.GetListWithContext(context.Background(), offset, pageSize)
+func (s *ProjectService) GetList(options *FilterOptions, offset int, pageSize int) (*SearchResultProject, *Response, error) {
+	return s.GetListWithContext(context.Background(), options, offset, pageSize)
 }
 
 // GetListWithContext retrieve project list with context
-// TODO: Implement search options
-func (s *ProjectService) GetListWithContext(ctx context.Context, offset int, pageSize int) (*SearchResultProject, *Response, error) {
+// Filters like FilterID or NewFilter("active", Equal, true) can be given in options, nil lists every project
+func (s *ProjectService) GetListWithContext(ctx context.Context, options *FilterOptions, offset int, pageSize int) (*SearchResultProject, *Response, error) {
 	apiEndpoint := "api/v3/projects"
-	Obj, Resp, err := GetListWithContext(ctx, s, apiEndpoint, nil, offset, pageSize)
+	Obj, Resp, err := GetListWithContext(ctx, s, apiEndpoint, options, offset, pageSize)
 	if err != nil {
 		return nil, Resp, err
 	}
@@ -2,8 +2,12 @@ package openproject
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 // QueryService handles statuses from the OpenProject instance / API.
@@ -11,9 +15,9 @@ type QueryService struct {
 	client *Client
 }
 
-// SearchResultQuery represent a list of Projects
+// SearchResultQuery represent a list of Queries
 type SearchResultQuery struct {
-	Embedded statusElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Embedded QueryElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
 	PaginationParam
 }
 
@@ -25,9 +29,14 @@ func (s *SearchResultQuery) ConcatEmbed(states interface{}) {
 	s.Embedded.Elements = append(s.Embedded.Elements, states.(*SearchResultQuery).Embedded.Elements...)
 }
 
-// QueryElements array of elements within a query
+// Elements returns the elements of the page
+func (s *SearchResultQuery) Elements() []QueryResult {
+	return s.Embedded.Elements
+}
+
+// QueryElements array of elements within a query list
 type QueryElements struct {
-	Elements []Status `json:"elements,omitempty" structs:"elements,omitempty"`
+	Elements []QueryResult `json:"elements,omitempty" structs:"elements,omitempty"`
 }
 
 // QueryResult represents a query. Use for single query result.
@@ -56,11 +65,13 @@ type QueryResult struct {
 		HighlightedAttributes []QueryEmbeddedColumn `json:"highlightedAttributes,omitempty" structs:"highlightedAttributes,omitempty"`
 		Results               struct {
 			PaginationParam
-			Type     string `json:"_type,omitempty" structs:"_type,omitempty"`
-			Embedded struct {
+			Type      string    `json:"_type,omitempty" structs:"_type,omitempty"`
+			Groups    []WPGroup `json:"groups,omitempty" structs:"groups,omitempty"`
+			TotalSums WPSums    `json:"totalSums,omitempty" structs:"totalSums,omitempty"`
+			Embedded  struct {
 				Elements []WorkPackage `json:"elements,omitempty" structs:"elements,omitempty"`
 				Schemas  Schemas       `json:"schemas,omitempty" structs:"schemas,omitempty"`
-			}
+			} `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
 			Links struct {
 				Self                       *OPGenericLink  `json:"self,omitempty" structs:"self,omitempty"`
 				JumpTo                     *OPGenericLink  `json:"jumpToWorkPackage,omitempty" structs:"jumpToWorkPackage,omitempty"`
@@ -99,17 +110,74 @@ type QueryResult struct {
 
 // QueryFilter filters within a query
 type QueryFilter struct {
-	Type  string `json:"_type,omitempty" structs:"_type,omitempty"`
-	Name  string `json:"name,omitempty" structs:"name,omitempty"`
-	Links struct {
+	Type string `json:"_type,omitempty" structs:"_type,omitempty"`
+	Name string `json:"name,omitempty" structs:"name,omitempty"`
+	// Values of filters on plain properties, like a subject text or a date. Resources values are in Links.Values.
+	Values []string `json:"values,omitempty" structs:"values,omitempty"`
+	Links  struct {
 		Schema   *OPGenericLink `json:"schema,omitempty" structs:"schema,omitempty"`
 		Filter   *OPGenericLink `json:"filter,omitempty" structs:"filter,omitempty"`
 		Operator *OPGenericLink `json:"operator,omitempty" structs:"operator,omitempty"`
-		// TODO: Complete fields
-		Values []interface{} `json:"values,omitempty"`
+		// Values of filters on resources, like statuses or users
+		Values []OPGenericLink `json:"values,omitempty" structs:"values,omitempty"`
+	} `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// Field returns the name of the filtered property, e.g. status
+func (f *QueryFilter) Field() string {
+	if f.Links.Filter == nil {
+		return ""
+	}
+	return lastPathSegment(f.Links.Filter.Href)
+}
+
+// Operator returns the operator of the filter, e.g. Open
+func (f *QueryFilter) Operator() SearchOperator {
+	if f.Links.Operator == nil {
+		return ""
+	}
+	return SearchOperator(lastPathSegment(f.Links.Operator.Href))
+}
+
+// Filter converts the query filter to a Filter, e.g. to run it along with other filters
+func (f *QueryFilter) Filter() Filter {
+	filter := NewFilter(f.Field(), f.Operator())
+	for _, value := range f.Values {
+		filter.Values = append(filter.Values, value)
+	}
+	for _, link := range f.Links.Values {
+		filter.Values = append(filter.Values, Href(link.Href))
+	}
+	return filter
+}
+
+// QueryForm is the form of a query, used to validate a query before creating or updating it
+type QueryForm struct {
+	Type     string `json:"_type,omitempty" structs:"_type,omitempty"`
+	Embedded struct {
+		Payload *QueryResult `json:"payload,omitempty" structs:"payload,omitempty"`
+		// Schema describes the query properties and their allowed values
+		Schema json.RawMessage `json:"schema,omitempty" structs:"schema,omitempty"`
+		// ValidationErrors of the payload keyed by property name
+		ValidationErrors map[string]*Error `json:"validationErrors,omitempty" structs:"validationErrors,omitempty"`
+	} `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Links struct {
+		Self     *OPGenericLink `json:"self,omitempty" structs:"self,omitempty"`
+		Validate *OPGenericLink `json:"validate,omitempty" structs:"validate,omitempty"`
+		Commit   *OPGenericLink `json:"commit,omitempty" structs:"commit,omitempty"`
 	} `json:"_links,omitempty" structs:"_links,omitempty"`
 }
 
+// lastPathSegment returns the unescaped last segment of an href, e.g. %3D for /api/v3/queries/operators/%3D
+func lastPathSegment(href string) string {
+	href = strings.TrimRight(href, "/")
+	segment := href[strings.LastIndex(href, "/")+1:]
+	if unescaped, err := url.PathUnescape(segment); err == nil {
+		return unescaped
+	}
+	return segment
+}
+
 type QueryEmbeddedColumn struct {
 	Type  string `json:"_type,omitempty"`
 	ID    string `json:"id,omitempty"`
@@ -143,11 +211,11 @@ func (s *QueryService) Get(queryID string) (*QueryResult, *Response, error) {
 	return s.GetWithContext(context.Background(), queryID)
 }
 
-// GetListWithContext Retrieve status list with context
-// TODO: Implement search parameters-options
-func (s *QueryService) GetListWithContext(ctx context.Context, offset int, pageSize int) (*SearchResultQuery, *Response, error) {
+// GetListWithContext Retrieve query list with context
+// Filters like FilterProject can be given in options, nil lists every query
+func (s *QueryService) GetListWithContext(ctx context.Context, options *FilterOptions, offset int, pageSize int) (*SearchResultQuery, *Response, error) {
 	apiEndpoint := "api/v3/queries"
-	Obj, Resp, err := GetListWithContext(ctx, s, apiEndpoint, nil, offset, pageSize)
+	Obj, Resp, err := GetListWithContext(ctx, s, apiEndpoint, options, offset, pageSize)
 	if err != nil {
 		return nil, Resp, err
 	}
@@ -155,8 +223,8 @@ func (s *QueryService) GetListWithContext(ctx context.Context, offset int, pageS
 }
 
 // GetList wraps GetListWithContext using the background context.
-func (s *QueryService) GetList(offset int, pageSize int) (*SearchResultQuery, *Response, error) {
-	return s.GetListWithContext(context.Background(), offset, pageSize)
+func (s *QueryService) GetList(options *FilterOptions, offset int, pageSize int) (*SearchResultQuery, *Response, error) {
+	return s.GetListWithContext(context.Background(), options, offset, pageSize)
 }
 
 // CreateWithContext creates a query from a JSON representation.
@@ -185,3 +253,161 @@ func (s *QueryService) DeleteWithContext(ctx context.Context, queryID string) (*
 func (s *QueryService) Delete(queryID string) (*Response, error) {
 	return s.DeleteWithContext(context.Background(), queryID)
 }
+
+// QueryPatch holds the properties of a query to update. Nil fields are left unchanged.
+// Usage case:
+//
+//	patch := &QueryPatch{Name: Ptr("Open bugs"), Public: Ptr(false)}
+//	query, _, err := client.Query.Update("9", patch)
+type QueryPatch struct {
+	Name               *string          `json:"name,omitempty" structs:"name,omitempty"`
+	Filters            []QueryFilter    `json:"filters,omitempty" structs:"filters,omitempty"`
+	IncludeSubprojects *bool            `json:"includeSubprojects,omitempty" structs:"includeSubprojects,omitempty"`
+	Sums               *bool            `json:"sums,omitempty" structs:"sums,omitempty"`
+	Public             *bool            `json:"public,omitempty" structs:"public,omitempty"`
+	Hidden             *bool            `json:"hidden,omitempty" structs:"hidden,omitempty"`
+	TimelineVisible    *bool            `json:"timelineVisible,omitempty" structs:"timelineVisible,omitempty"`
+	ShowHierarchies    *bool            `json:"showHierarchies,omitempty" structs:"showHierarchies,omitempty"`
+	HighlightingMode   *string          `json:"highlightingMode,omitempty" structs:"highlightingMode,omitempty"`
+	TimelineZoomLevel  *string          `json:"timelineZoomLevel,omitempty" structs:"timelineZoomLevel,omitempty"`
+	Links              *QueryPatchLinks `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// QueryPatchLinks are the links of a query to update. Nil fields are left unchanged.
+type QueryPatchLinks struct {
+	Project *OPGenericLink  `json:"project,omitempty" structs:"project,omitempty"`
+	Columns []OPGenericLink `json:"columns,omitempty" structs:"columns,omitempty"`
+	SortBy  []OPGenericLink `json:"sortBy,omitempty" structs:"sortBy,omitempty"`
+	GroupBy *OPGenericLink  `json:"groupBy,omitempty" structs:"groupBy,omitempty"`
+}
+
+// UpdateWithContext updates a query. Only the fields set in patch are sent.
+func (s *QueryService) UpdateWithContext(ctx context.Context, queryID string, patch *QueryPatch) (*QueryResult, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/queries/%s", queryID)
+	obj, resp, err := UpdateWithContext(ctx, patch, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*QueryResult), resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *QueryService) Update(queryID string, patch *QueryPatch) (*QueryResult, *Response, error) {
+	return s.UpdateWithContext(context.Background(), queryID, patch)
+}
+
+// StarWithContext stars a query, so it is listed in the favorite views of the user
+func (s *QueryService) StarWithContext(ctx context.Context, queryID string) (*QueryResult, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/queries/%s/star", queryID)
+	obj, resp, err := UpdateWithContext(ctx, nil, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*QueryResult), resp, err
+}
+
+// Star wraps StarWithContext using the background context.
+func (s *QueryService) Star(queryID string) (*QueryResult, *Response, error) {
+	return s.StarWithContext(context.Background(), queryID)
+}
+
+// UnstarWithContext removes the star of a query
+func (s *QueryService) UnstarWithContext(ctx context.Context, queryID string) (*QueryResult, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/queries/%s/unstar", queryID)
+	obj, resp, err := UpdateWithContext(ctx, nil, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*QueryResult), resp, err
+}
+
+// Unstar wraps UnstarWithContext using the background context.
+func (s *QueryService) Unstar(queryID string) (*QueryResult, *Response, error) {
+	return s.UnstarWithContext(context.Background(), queryID)
+}
+
+// GetDefaultWithContext gets the default query, the one used when no query is selected.
+// The default query of a project is returned if projectID is given, the global one otherwise.
+func (s *QueryService) GetDefaultWithContext(ctx context.Context, projectID string) (*QueryResult, *Response, error) {
+	apiEndpoint := "api/v3/queries/default"
+	if projectID != "" {
+		apiEndpoint = fmt.Sprintf("api/v3/projects/%s/queries/default", projectID)
+	}
+	obj, resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*QueryResult), resp, err
+}
+
+// GetDefault wraps GetDefaultWithContext using the background context.
+func (s *QueryService) GetDefault(projectID string) (*QueryResult, *Response, error) {
+	return s.GetDefaultWithContext(context.Background(), projectID)
+}
+
+// FormWithContext validates queryObj against the query form, the one of a new query if queryID is empty.
+// Validation errors are returned in the form, not as error.
+func (s *QueryService) FormWithContext(ctx context.Context, queryID string, queryObj *QueryResult) (*QueryForm, *Response, error) {
+	apiEndpoint := "api/v3/queries/form"
+	if queryID != "" {
+		apiEndpoint = fmt.Sprintf("api/v3/queries/%s/form", queryID)
+	}
+	req, err := s.client.NewRequestWithContext(ctx, "POST", apiEndpoint, queryObj)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	form := new(QueryForm)
+	resp, err := s.client.Do(req, form)
+	if err != nil {
+		return nil, resp, NewOpenProjectError(resp, err)
+	}
+	return form, resp, nil
+}
+
+// Form wraps FormWithContext using the background context.
+func (s *QueryService) Form(queryID string, queryObj *QueryResult) (*QueryForm, *Response, error) {
+	return s.FormWithContext(context.Background(), queryID, queryObj)
+}
+
+// GetResultsWithContext runs a saved query and returns a page of the work-packages it matches,
+// with the filters, sorting and grouping of the query.
+func (s *QueryService) GetResultsWithContext(ctx context.Context, queryID string, offset int, pageSize int) (*SearchResultWP, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/queries/%s", queryID)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	values := make(url.Values)
+	values.Add(kOffset, strconv.Itoa(offset))
+	values.Add(kPageSize, strconv.Itoa(pageSize))
+	req.URL.RawQuery = values.Encode()
+
+	query := new(QueryResult)
+	resp, err := s.client.Do(req, query)
+	if err != nil {
+		return nil, resp, NewOpenProjectError(resp, err)
+	}
+
+	results := query.Embedded.Results
+	page := &SearchResultWP{
+		Embedded:        SearchEmbeddedWP{Elements: results.Embedded.Elements},
+		PaginationParam: results.PaginationParam,
+		Groups:          results.Groups,
+		TotalSums:       results.TotalSums,
+	}
+	resp.populatePageValues(page)
+	return page, resp, nil
+}
+
+// GetResults wraps GetResultsWithContext using the background context.
+func (s *QueryService) GetResults(queryID string, offset int, pageSize int) (*SearchResultWP, *Response, error) {
+	return s.GetResultsWithContext(context.Background(), queryID, offset, pageSize)
+}
+
+// ResultsPager returns a Pager over every work-package matched by a saved query
+func (s *QueryService) ResultsPager(queryID string) *Pager[WorkPackage] {
+	return NewPager[WorkPackage](func(ctx context.Context, offset int, pageSize int) (*SearchResultWP, *Response, error) {
+		return s.GetResultsWithContext(ctx, queryID, offset, pageSize)
+	})
+}
@@ -1,7 +1,10 @@
 package openproject
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"testing"
@@ -50,7 +53,7 @@ func TestQueryService_GetList_Success(t *testing.T) {
 		}
 	})
 
-	queries, _, err := testClient.Query.GetList(0, 10)
+	queries, _, err := testClient.Query.GetList(nil, 0, 10)
 	if queries == nil {
 		t.Error("Expected query list but received nil")
 		return
@@ -130,3 +133,186 @@ func TestQueryService_Delete(t *testing.T) {
 		t.Errorf("Error given: %s", err)
 	}
 }
+
+func TestQueryService_GetList_Queries(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-queries-no-filters.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/queries", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, string(raw))
+	})
+
+	queries, _, err := testClient.Query.GetList(nil, 1, 10)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	query := queries.Elements()[0]
+	if query.ID == 0 || len(query.Filters) == 0 {
+		t.Fatalf("Expected queries to be decoded. Got %+v", query)
+	}
+	if field, operator := query.Filters[0].Field(), query.Filters[0].Operator(); field != "manualSort" || operator != OrderedWorkPackages {
+		t.Errorf("Expected manualSort ow filter. Got %s %s", field, operator)
+	}
+}
+
+func TestQueryService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/queries/9", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		body, _ := io.ReadAll(r.Body)
+		want := `{"name":"Renamed","public":false,"_links":{"columns":[{"href":"/api/v3/queries/columns/id"}]}}`
+		if string(body) != want+"\n" {
+			t.Errorf("Expected body %s. Got %s", want, body)
+		}
+		fmt.Fprint(w, `{"_type":"Query","id":9,"name":"Renamed"}`)
+	})
+
+	patch := &QueryPatch{
+		Name:   Ptr("Renamed"),
+		Public: Ptr(false),
+		Links:  &QueryPatchLinks{Columns: []OPGenericLink{{Href: "/api/v3/queries/columns/id"}}},
+	}
+	query, _, err := testClient.Query.Update("9", patch)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if query.Name != "Renamed" {
+		t.Errorf("Expected query Renamed. Got %s", query.Name)
+	}
+}
+
+func TestQueryService_StarUnstar(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/queries/9/star", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		fmt.Fprint(w, `{"_type":"Query","id":9,"starred":true}`)
+	})
+	testMux.HandleFunc("/api/v3/queries/9/unstar", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		fmt.Fprint(w, `{"_type":"Query","id":9,"starred":false}`)
+	})
+
+	query, _, err := testClient.Query.Star("9")
+	if err != nil || !query.Starred {
+		t.Errorf("Expected starred query. Got %+v, %v", query, err)
+	}
+	query, _, err = testClient.Query.Unstar("9")
+	if err != nil || query.Starred {
+		t.Errorf("Expected unstarred query. Got %+v, %v", query, err)
+	}
+}
+
+func TestQueryService_GetDefault(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/queries/default", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"_type":"Query","name":"default"}`)
+	})
+	testMux.HandleFunc("/api/v3/projects/demo-project/queries/default", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"_type":"Query","name":"project default"}`)
+	})
+
+	if query, _, err := testClient.Query.GetDefault(""); err != nil || query.Name != "default" {
+		t.Errorf("Expected the global default query. Got %+v, %v", query, err)
+	}
+	if query, _, err := testClient.Query.GetDefault("demo-project"); err != nil || query.Name != "project default" {
+		t.Errorf("Expected the project default query. Got %+v, %v", query, err)
+	}
+}
+
+func TestQueryService_Form(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/queries/form", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"_type":"Form","_embedded":{"payload":{"name":""},"schema":{"_type":"Schema"},
+			"validationErrors":{"name":{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:PropertyConstraintViolation","message":"Name can't be blank."}}},
+			"_links":{"validate":{"href":"/api/v3/queries/form","method":"post"}}}`)
+	})
+
+	form, _, err := testClient.Query.Form("", &QueryResult{})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if nameErr := form.Embedded.ValidationErrors["name"]; nameErr == nil || nameErr.Message != "Name can't be blank." {
+		t.Errorf("Expected name validation error. Got %+v", form.Embedded.ValidationErrors)
+	}
+}
+
+func TestQueryService_ResultsPager(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-query.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/queries/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("offset") == "2" {
+			fmt.Fprint(w, `{"_type":"Query","id":1,"_embedded":{"results":{"total":21,"count":1,"pageSize":20,"offset":2,
+				"_embedded":{"elements":[{"id":1000,"subject":"Last"}]}}}}`)
+			return
+		}
+		fmt.Fprint(w, string(raw))
+	})
+
+	results, resp, err := testClient.Query.GetResults("1", 1, 20)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(results.Elements()) != 20 || resp.Total != 21 || results.TotalPage() != 2 {
+		t.Errorf("Unexpected results page %+v", results.PaginationParam)
+	}
+
+	pager := testClient.Query.ResultsPager("1")
+	pager.PageSize = 20
+	it := pager.Iterate(context.Background())
+	defer it.Close()
+	count := 0
+	var last WorkPackage
+	for it.Next() {
+		count++
+		last = it.Value()
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if count != 21 || last.Subject != "Last" {
+		t.Errorf("Expected 21 work-packages ending with Last. Got %d ending with %s", count, last.Subject)
+	}
+}
+
+func TestQueryFilter_Filter(t *testing.T) {
+	raw, err := os.ReadFile("./mocks/get/get-query.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	query := new(QueryResult)
+	if err := json.Unmarshal(raw, query); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	filter := query.Filters[0].Filter()
+	if filter.Field != "status" || filter.Operator != Open {
+		t.Errorf("Expected open status filter. Got %+v", filter)
+	}
+
+	queryFilter := QueryFilter{}
+	queryFilter.Links.Filter = &OPGenericLink{Href: "/api/v3/queries/filters/assignee"}
+	queryFilter.Links.Operator = &OPGenericLink{Href: "/api/v3/queries/operators/%3D"}
+	queryFilter.Links.Values = []OPGenericLink{{Href: "/api/v3/users/5"}}
+	values, err := NewFilterOptions(queryFilter.Filter()).prepareFilters(nil)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if got := values.Get("filters"); got != `[{"assignee":{"operator":"=","values":["5"]}}]` {
+		t.Errorf("Unexpected filters %s", got)
+	}
+}
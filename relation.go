@@ -0,0 +1,400 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// RelationService handles relations between work-packages for the OpenProject instance / API.
+type RelationService struct {
+	client *Client
+}
+
+// RelationType is the kind of a relation, seen from the work-package the relation goes from
+type RelationType string
+
+// Constants to represent OpenProject relation types
+const (
+	// RelationRelates from is related to to
+	RelationRelates RelationType = "relates"
+	// RelationDuplicates from duplicates to
+	RelationDuplicates RelationType = "duplicates"
+	// RelationDuplicated from is duplicated by to
+	RelationDuplicated RelationType = "duplicated"
+	// RelationBlocks from blocks to
+	RelationBlocks RelationType = "blocks"
+	// RelationBlocked from is blocked by to
+	RelationBlocked RelationType = "blocked"
+	// RelationPrecedes from precedes to, to can not start before from ends (plus lag)
+	RelationPrecedes RelationType = "precedes"
+	// RelationFollows from follows to, from can not start before to ends (plus lag)
+	RelationFollows RelationType = "follows"
+	// RelationIncludes from includes to
+	RelationIncludes RelationType = "includes"
+	// RelationPartOf from is part of to
+	RelationPartOf RelationType = "partof"
+	// RelationRequires from requires to
+	RelationRequires RelationType = "requires"
+	// RelationRequired from is required by to
+	RelationRequired RelationType = "required"
+)
+
+// Relation is the object representing a relation between two OpenProject work-packages
+type Relation struct {
+	Type        string       `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID          int          `json:"id,omitempty" structs:"id,omitempty"`
+	Name        string       `json:"name,omitempty" structs:"name,omitempty"`
+	RelType     RelationType `json:"type,omitempty" structs:"type,omitempty"`
+	ReverseType RelationType `json:"reverseType,omitempty" structs:"reverseType,omitempty"`
+	Description string       `json:"description,omitempty" structs:"description,omitempty"`
+	// Lag is the number of working days between the end of the predecessor and the start of the follower.
+	// Older OpenProject versions call it delay.
+	Lag   int           `json:"lag,omitempty" structs:"lag,omitempty"`
+	Delay int           `json:"delay,omitempty" structs:"delay,omitempty"`
+	Links RelationLinks `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// RelationPatch holds the changes of a relation update. Nil fields are left unchanged, so the lag can be reset with
+// &RelationPatch{Lag: Ptr(0)} and the description cleared with &RelationPatch{Description: Ptr("")}.
+type RelationPatch struct {
+	RelType     *RelationType `json:"type,omitempty" structs:"type,omitempty"`
+	Description *string       `json:"description,omitempty" structs:"description,omitempty"`
+	Lag         *int          `json:"lag,omitempty" structs:"lag,omitempty"`
+}
+
+// RelationLinks are Relation Links
+type RelationLinks struct {
+	Self              *OPGenericLink `json:"self,omitempty" structs:"self,omitempty"`
+	Update            *OPGenericLink `json:"update,omitempty" structs:"update,omitempty"`
+	UpdateImmediately *OPGenericLink `json:"updateImmediately,omitempty" structs:"updateImmediately,omitempty"`
+	Delete            *OPGenericLink `json:"delete,omitempty" structs:"delete,omitempty"`
+	From              *OPGenericLink `json:"from,omitempty" structs:"from,omitempty"`
+	To                *OPGenericLink `json:"to,omitempty" structs:"to,omitempty"`
+}
+
+// FromID returns the ID of the work-package the relation goes from, 0 if unknown
+func (r *Relation) FromID() int {
+	return linkID(r.Links.From)
+}
+
+// ToID returns the ID of the work-package the relation goes to, 0 if unknown
+func (r *Relation) ToID() int {
+	return linkID(r.Links.To)
+}
+
+// lagDays returns the lag of the relation, whichever version of the API sent it
+func (r *Relation) lagDays() int {
+	if r.Lag != 0 {
+		return r.Lag
+	}
+	return r.Delay
+}
+
+// linkID returns the numeric ID at the end of a link href, 0 if there is none
+func linkID(link *OPGenericLink) int {
+	id, _ := strconv.Atoi(link.LinkID())
+	return id
+}
+
+// SearchResultRelation represent a list of relations
+type SearchResultRelation struct {
+	Embedded relationElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	PaginationParam
+}
+
+func (s *SearchResultRelation) TotalPage() int {
+	return int(math.Ceil(float64(s.Total) / float64(s.PageSize)))
+}
+
+func (s *SearchResultRelation) ConcatEmbed(relations interface{}) {
+	s.Embedded.Elements = append(s.Embedded.Elements, relations.(*SearchResultRelation).Embedded.Elements...)
+}
+
+// Elements returns the relations of the page
+func (s *SearchResultRelation) Elements() []Relation {
+	return s.Embedded.Elements
+}
+
+// relationElements array wraps elements within SearchResultRelation
+type relationElements struct {
+	Elements []Relation `json:"elements,omitempty" structs:"elements,omitempty"`
+}
+
+// FilterInvolved filters relations going from or to a work-package, e.g. FilterInvolved(42)
+func FilterInvolved(args ...interface{}) Filter {
+	return fieldFilter("involved", args)
+}
+
+// FilterRelationType filters relations by type, e.g. FilterRelationType(RelationPrecedes, RelationFollows)
+func FilterRelationType(types ...RelationType) Filter {
+	values := make([]interface{}, 0, len(types))
+	for _, relationType := range types {
+		values = append(values, string(relationType))
+	}
+	return NewFilter("type", Equal, values...)
+}
+
+// GetWithContext gets a relation from OpenProject using its ID
+func (s *RelationService) GetWithContext(ctx context.Context, relationID string) (*Relation, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/relations/%s", relationID)
+	obj, resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Relation), resp, err
+}
+
+// Get wraps GetWithContext using the background context.
+func (s *RelationService) Get(relationID string) (*Relation, *Response, error) {
+	return s.GetWithContext(context.Background(), relationID)
+}
+
+// GetListWithContext retrieves relations with context.
+// They can be filtered by work-package and type with FilterInvolved and FilterRelationType.
+func (s *RelationService) GetListWithContext(ctx context.Context, options *FilterOptions, offset int, pageSize int) (*SearchResultRelation, *Response, error) {
+	apiEndpoint := "api/v3/relations"
+	obj, resp, err := GetListWithContext(ctx, s, apiEndpoint, options, offset, pageSize)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*SearchResultRelation), resp, err
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *RelationService) GetList(options *FilterOptions, offset int, pageSize int) (*SearchResultRelation, *Response, error) {
+	return s.GetListWithContext(context.Background(), options, offset, pageSize)
+}
+
+// GetByWorkPackageWithContext retrieves every relation going from or to a work-package
+func (s *RelationService) GetByWorkPackageWithContext(ctx context.Context, workPackageID string) ([]Relation, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/work_packages/%s/relations", workPackageID)
+	relations := new(SearchResultRelation)
+	resp, err := getCollection(ctx, s.client, apiEndpoint, relations)
+	if err != nil {
+		return nil, resp, err
+	}
+	return relations.Elements(), resp, nil
+}
+
+// GetByWorkPackage wraps GetByWorkPackageWithContext using the background context.
+func (s *RelationService) GetByWorkPackage(workPackageID string) ([]Relation, *Response, error) {
+	return s.GetByWorkPackageWithContext(context.Background(), workPackageID)
+}
+
+// CreateWithContext creates a relation from a work-package. The other work-package is given by relation.Links.To.
+func (s *RelationService) CreateWithContext(ctx context.Context, fromWorkPackageID string, relation *Relation) (*Relation, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/work_packages/%s/relations", fromWorkPackageID)
+	obj, resp, err := CreateWithContext(ctx, relation, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Relation), resp, err
+}
+
+// Create wraps CreateWithContext using the background context.
+func (s *RelationService) Create(fromWorkPackageID string, relation *Relation) (*Relation, *Response, error) {
+	return s.CreateWithContext(context.Background(), fromWorkPackageID, relation)
+}
+
+// UpdateWithContext updates the type, description or lag of a relation. Its work-packages can not be changed.
+func (s *RelationService) UpdateWithContext(ctx context.Context, relationID string, patch *RelationPatch) (*Relation, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/relations/%s", relationID)
+	obj, resp, err := UpdateWithContext(ctx, patch, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Relation), resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *RelationService) Update(relationID string, patch *RelationPatch) (*Relation, *Response, error) {
+	return s.UpdateWithContext(context.Background(), relationID, patch)
+}
+
+// DeleteWithContext deletes a relation
+func (s *RelationService) DeleteWithContext(ctx context.Context, relationID string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/relations/%s", relationID)
+	return DeleteWithContext(ctx, s, apiEndpoint)
+}
+
+// Delete wraps DeleteWithContext using the background context.
+func (s *RelationService) Delete(relationID string) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), relationID)
+}
+
+// ErrDependencyCycle is returned when work-packages depend on each other in a loop
+var ErrDependencyCycle = errors.New("openproject: dependency cycle between work-packages")
+
+// Dependency is an edge of a DependencyGraph: To can not start before From is done
+type Dependency struct {
+	From int
+	To   int
+	// Lag is the number of working days between the end of From and the start of To
+	Lag int
+	// Type is blocks or precedes
+	Type RelationType
+}
+
+// DependencyGraph is the graph of the precedes/follows and blocks/blocked relations between a set of work-packages.
+// Usage case:
+//
+//	graph := NewDependencyGraph(workPackages, relations)
+//	if cycles := graph.Cycles(); len(cycles) > 0 { ... }
+//	order, err := graph.Order()
+type DependencyGraph struct {
+	nodes map[int]bool
+	edges map[int][]Dependency
+}
+
+// NewDependencyGraph builds the dependency graph of the work-packages.
+// Relations of other types, or with a work-package not in the set, are ignored.
+func NewDependencyGraph(workPackages []WorkPackage, relations []Relation) *DependencyGraph {
+	g := &DependencyGraph{nodes: make(map[int]bool), edges: make(map[int][]Dependency)}
+	for _, wp := range workPackages {
+		g.nodes[wp.ID] = true
+	}
+
+	seen := make(map[Dependency]bool)
+	for i := range relations {
+		relation := &relations[i]
+		from, to := relation.FromID(), relation.ToID()
+		dependency := Dependency{Lag: relation.lagDays()}
+		switch relation.RelType {
+		case RelationPrecedes, RelationBlocks:
+			dependency.From, dependency.To, dependency.Type = from, to, relation.RelType
+		case RelationFollows:
+			dependency.From, dependency.To, dependency.Type = to, from, RelationPrecedes
+		case RelationBlocked:
+			dependency.From, dependency.To, dependency.Type = to, from, RelationBlocks
+		default:
+			continue
+		}
+		// The same relation is listed from both of its work-packages
+		if !g.nodes[dependency.From] || !g.nodes[dependency.To] || seen[dependency] {
+			continue
+		}
+		seen[dependency] = true
+		g.edges[dependency.From] = append(g.edges[dependency.From], dependency)
+	}
+	return g
+}
+
+// Successors returns the dependencies of the work-packages waiting for workPackageID
+func (g *DependencyGraph) Successors(workPackageID int) []Dependency {
+	return g.edges[workPackageID]
+}
+
+// Predecessors returns the dependencies workPackageID waits for
+func (g *DependencyGraph) Predecessors(workPackageID int) []Dependency {
+	var predecessors []Dependency
+	for _, id := range g.sortedNodes() {
+		for _, dependency := range g.edges[id] {
+			if dependency.To == workPackageID {
+				predecessors = append(predecessors, dependency)
+			}
+		}
+	}
+	return predecessors
+}
+
+// Cycles returns the work-package IDs of dependency cycles, each cycle starting with its lowest ID.
+// It reports at least one cycle per group of work-packages depending on each other, but not every cycle of a group
+// when several loops share work-packages. It is empty when the graph is acyclic.
+func (g *DependencyGraph) Cycles() [][]int {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[int]int)
+	var stack []int
+	var cycles [][]int
+
+	var visit func(id int)
+	visit = func(id int) {
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, dependency := range g.edges[id] {
+			switch state[dependency.To] {
+			case unvisited:
+				visit(dependency.To)
+			case visiting:
+				// The cycle is the part of the stack from the work-package reached again
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == dependency.To {
+						cycles = append(cycles, rotateCycle(stack[i:]))
+						break
+					}
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+	}
+	for _, id := range g.sortedNodes() {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+	return cycles
+}
+
+// Order returns the work-package IDs so that every work-package comes after the ones it depends on.
+// It fails with ErrDependencyCycle when there is a cycle.
+func (g *DependencyGraph) Order() ([]int, error) {
+	inDegree := make(map[int]int)
+	for _, dependencies := range g.edges {
+		for _, dependency := range dependencies {
+			inDegree[dependency.To]++
+		}
+	}
+
+	var ready []int
+	for _, id := range g.sortedNodes() {
+		if inDegree[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+	order := make([]int, 0, len(g.nodes))
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+		for _, dependency := range g.edges[id] {
+			inDegree[dependency.To]--
+			if inDegree[dependency.To] == 0 {
+				ready = append(ready, dependency.To)
+			}
+		}
+	}
+	if len(order) != len(g.nodes) {
+		return nil, errors.Wrapf(ErrDependencyCycle, "%v", g.Cycles())
+	}
+	return order, nil
+}
+
+// sortedNodes returns the work-package IDs in ascending order, for deterministic results
+func (g *DependencyGraph) sortedNodes() []int {
+	ids := make([]int, 0, len(g.nodes))
+	for id := range g.nodes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// rotateCycle returns a copy of cycle starting with its lowest ID
+func rotateCycle(cycle []int) []int {
+	lowest := 0
+	for i, id := range cycle {
+		if id < cycle[lowest] {
+			lowest = i
+		}
+	}
+	return append(append([]int{}, cycle[lowest:]...), cycle[:lowest]...)
+}
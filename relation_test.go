@@ -0,0 +1,183 @@
+package openproject
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestRelationService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/relations/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/api/v3/relations/1")
+
+		fmt.Fprint(w, `{"_type":"Relation","id":1,"type":"precedes","reverseType":"follows","lag":2,
+			"_links":{"from":{"href":"/api/v3/work_packages/10"},"to":{"href":"/api/v3/work_packages/11"}}}`)
+	})
+
+	relation, _, err := testClient.Relation.Get("1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if relation.RelType != RelationPrecedes || relation.Lag != 2 || relation.FromID() != 10 || relation.ToID() != 11 {
+		t.Errorf("Unexpected relation %+v", relation)
+	}
+}
+
+func TestRelationService_GetList(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-relations-filtered.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/relations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/api/v3/relations")
+
+		want := `[{"involved":{"operator":"=","values":["11"]}},{"type":{"operator":"=","values":["precedes","follows"]}}]`
+		if got := r.URL.Query().Get("filters"); got != want {
+			t.Errorf("Expected filters %s. Got %s", want, got)
+		}
+		fmt.Fprint(w, string(raw))
+	})
+
+	opt := NewFilterOptions(FilterInvolved(11), FilterRelationType(RelationPrecedes, RelationFollows))
+	relations, resp, err := testClient.Relation.GetList(opt, 1, 20)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(relations.Elements()) != 3 || resp.Total != 3 {
+		t.Errorf("Expected 3 relations. Got %d (total %d)", len(relations.Elements()), resp.Total)
+	}
+}
+
+func TestRelationService_WorkPackageRelations(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-relations-filtered.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/work_packages/11/relations", func(w http.ResponseWriter, r *http.Request) {
+		testRequestURL(t, r, "/api/v3/work_packages/11/relations")
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, string(raw))
+		case "POST":
+			var relation Relation
+			if err := json.NewDecoder(r.Body).Decode(&relation); err != nil {
+				t.Error(err)
+			}
+			if relation.RelType != RelationBlocks || relation.Links.To.Href != "/api/v3/work_packages/12" {
+				t.Errorf("Unexpected relation payload %+v", relation)
+			}
+			relation.ID = 4
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(relation)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	relations, _, err := testClient.Relation.GetByWorkPackage("11")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(relations) != 3 {
+		t.Errorf("Expected 3 relations. Got %d", len(relations))
+	}
+
+	relation, _, err := testClient.Relation.Create("11", &Relation{
+		RelType: RelationBlocks,
+		Links:   RelationLinks{To: &OPGenericLink{Href: "/api/v3/work_packages/12"}},
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if relation.ID != 4 {
+		t.Errorf("Expected relation 4. Got %d", relation.ID)
+	}
+}
+
+func TestRelationService_UpdateDelete(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/relations/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PATCH":
+			body, _ := io.ReadAll(r.Body)
+			if want := `{"description":"","lag":0}` + "\n"; string(body) != want {
+				t.Errorf("Expected body %s. Got %s", want, body)
+			}
+			fmt.Fprint(w, `{"_type":"Relation","id":1,"type":"precedes","lag":0}`)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	relation, _, err := testClient.Relation.Update("1", &RelationPatch{Description: Ptr(""), Lag: Ptr(0)})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if relation.Lag != 0 || relation.Description != "" {
+		t.Errorf("Expected lag and description reset. Got %+v", relation)
+	}
+	if resp, err := testClient.Relation.Delete("1"); err != nil || resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected relation deleted. Got %v", err)
+	}
+}
+
+func TestDependencyGraph(t *testing.T) {
+	raw, err := os.ReadFile("./mocks/get/get-relations-filtered.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result SearchResultRelation
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatal(err)
+	}
+	workPackages := []WorkPackage{{ID: 12}, {ID: 11}, {ID: 10}}
+	// The relations of both work-packages are listed, so relation 1 appears twice
+	relations := append(result.Elements(), result.Elements()[0])
+
+	graph := NewDependencyGraph(workPackages, relations)
+	order, err := graph.Order()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !reflect.DeepEqual(order, []int{10, 11, 12}) {
+		t.Errorf("Expected order [10 11 12]. Got %v", order)
+	}
+	if successors := graph.Successors(10); len(successors) != 1 || successors[0].Lag != 2 {
+		t.Errorf("Expected 10 to precede 11 with a lag of 2. Got %+v", successors)
+	}
+	if predecessors := graph.Predecessors(12); len(predecessors) != 1 || predecessors[0].From != 11 {
+		t.Errorf("Expected 12 to follow 11. Got %+v", predecessors)
+	}
+
+	// 12 blocks 10 closes the loop 10 -> 11 -> 12 -> 10
+	relations = append(relations, Relation{
+		RelType: RelationBlocked,
+		Links: RelationLinks{
+			From: &OPGenericLink{Href: "/api/v3/work_packages/10"},
+			To:   &OPGenericLink{Href: "/api/v3/work_packages/12"},
+		},
+	})
+	graph = NewDependencyGraph(workPackages, relations)
+	if cycles := graph.Cycles(); !reflect.DeepEqual(cycles, [][]int{{10, 11, 12}}) {
+		t.Errorf("Expected cycle [10 11 12]. Got %v", cycles)
+	}
+	if _, err := graph.Order(); !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("Expected ErrDependencyCycle. Got %v", err)
+	}
+}
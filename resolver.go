@@ -0,0 +1,151 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNameNotFound is returned by the Resolver when no type or priority has the requested name
+var ErrNameNotFound = errors.New("openproject: name not found")
+
+// Resolver maps the names of types and priorities to their links, to fill work-package payloads from human input.
+// Names are matched case-insensitively. Each list is fetched once per client and kept until Reset.
+// Lists are fetched without holding the lock, so concurrent first lookups may fetch the same list more than once.
+// Usage case:
+//
+//	bug, err := client.Resolver.TypeLink("bug")
+//	high, err := client.Resolver.PriorityLink("High")
+//	wp := &WorkPackage{Subject: subject, Links: &WPLinks{Type: bug, Priority: high}}
+type Resolver struct {
+	client *Client
+
+	mu           sync.Mutex
+	types        map[string]OPGenericLink
+	priorities   map[string]OPGenericLink
+	projectTypes map[string]map[string]OPGenericLink
+	// generation is incremented by Reset, so that lists fetched before are not cached
+	generation int
+}
+
+// TypeLinkWithContext returns the link of the type named name
+func (r *Resolver) TypeLinkWithContext(ctx context.Context, name string) (*OPGenericLink, error) {
+	r.mu.Lock()
+	links, generation := r.types, r.generation
+	r.mu.Unlock()
+	if links == nil {
+		list, _, err := r.client.Type.GetListWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		links = typeLinks(list)
+		r.install(generation, func() { r.types = links })
+	}
+	return lookupName(links, "type", name)
+}
+
+// TypeLink wraps TypeLinkWithContext using the background context.
+func (r *Resolver) TypeLink(name string) (*OPGenericLink, error) {
+	return r.TypeLinkWithContext(context.Background(), name)
+}
+
+// ProjectTypeLinkWithContext returns the link of the type named name, only if it is enabled in the project
+func (r *Resolver) ProjectTypeLinkWithContext(ctx context.Context, projectID string, name string) (*OPGenericLink, error) {
+	r.mu.Lock()
+	links, generation := r.projectTypes[projectID], r.generation
+	r.mu.Unlock()
+	if links == nil {
+		list, _, err := r.client.Type.GetByProjectWithContext(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		links = typeLinks(list)
+		r.install(generation, func() {
+			if r.projectTypes == nil {
+				r.projectTypes = make(map[string]map[string]OPGenericLink)
+			}
+			r.projectTypes[projectID] = links
+		})
+	}
+	return lookupName(links, "type", name)
+}
+
+// ProjectTypeLink wraps ProjectTypeLinkWithContext using the background context.
+func (r *Resolver) ProjectTypeLink(projectID string, name string) (*OPGenericLink, error) {
+	return r.ProjectTypeLinkWithContext(context.Background(), projectID, name)
+}
+
+// PriorityLinkWithContext returns the link of the priority named name
+func (r *Resolver) PriorityLinkWithContext(ctx context.Context, name string) (*OPGenericLink, error) {
+	r.mu.Lock()
+	links, generation := r.priorities, r.generation
+	r.mu.Unlock()
+	if links == nil {
+		list, _, err := r.client.Priority.GetListWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		links = make(map[string]OPGenericLink)
+		for _, priority := range list.Elements() {
+			links[normalizeName(priority.Name)] = resolvedLink(priority.Links.Self, "priorities", priority.ID, priority.Name)
+		}
+		r.install(generation, func() { r.priorities = links })
+	}
+	return lookupName(links, "priority", name)
+}
+
+// PriorityLink wraps PriorityLinkWithContext using the background context.
+func (r *Resolver) PriorityLink(name string) (*OPGenericLink, error) {
+	return r.PriorityLinkWithContext(context.Background(), name)
+}
+
+// Reset forgets the cached names, e.g. after types or priorities were renamed
+func (r *Resolver) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types, r.priorities, r.projectTypes = nil, nil, nil
+	r.generation++
+}
+
+// install caches a list fetched without holding the lock, unless Reset was called since the fetch started
+func (r *Resolver) install(generation int, set func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.generation == generation {
+		set()
+	}
+}
+
+// typeLinks indexes the links of the types by name
+func typeLinks(list *TypeList) map[string]OPGenericLink {
+	links := make(map[string]OPGenericLink)
+	for _, t := range list.Elements() {
+		links[normalizeName(t.Name)] = resolvedLink(t.Links.Self, "types", t.ID, t.Name)
+	}
+	return links
+}
+
+// resolvedLink returns the self link of a resource, built from its ID when missing
+func resolvedLink(self *OPGenericLink, collection string, id int, name string) OPGenericLink {
+	if self != nil && self.Href != "" {
+		return OPGenericLink{Href: self.Href, Title: name}
+	}
+	return OPGenericLink{Href: fmt.Sprintf("/api/v3/%s/%d", collection, id), Title: name}
+}
+
+// lookupName returns a copy of the link named name
+func lookupName(links map[string]OPGenericLink, kind string, name string) (*OPGenericLink, error) {
+	link, ok := links[normalizeName(name)]
+	if !ok {
+		return nil, errors.Wrapf(ErrNameNotFound, "%s %q", kind, name)
+	}
+	return &link, nil
+}
+
+// normalizeName returns the key names are matched with
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
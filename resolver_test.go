@@ -0,0 +1,115 @@
+package openproject
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResolver(t *testing.T) {
+	setup()
+	defer teardown()
+	var requests int32
+	for endpoint, mock := range map[string]string{
+		"/api/v3/types":                       "./mocks/get/get-types.json",
+		"/api/v3/projects/demo-project/types": "./mocks/get/get-types.json",
+		"/api/v3/priorities":                  "./mocks/get/get-priorities.json",
+	} {
+		raw, err := os.ReadFile(mock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testMux.HandleFunc(endpoint, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			fmt.Fprint(w, string(raw))
+		})
+	}
+
+	bug, err := testClient.Resolver.TypeLink("bug")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if bug.Href != "/api/v3/types/7" || bug.Title != "Bug" {
+		t.Errorf("Unexpected link %+v", bug)
+	}
+	high, err := testClient.Resolver.PriorityLink(" HIGH ")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if high.Href != "/api/v3/priorities/9" {
+		t.Errorf("Unexpected link %+v", high)
+	}
+	if _, err := testClient.Resolver.ProjectTypeLink("demo-project", "Milestone"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+
+	// Lists are cached per client
+	testClient.Resolver.TypeLink("Task")
+	testClient.Resolver.PriorityLink("Low")
+	testClient.Resolver.ProjectTypeLink("demo-project", "Task")
+	if requests != 3 {
+		t.Errorf("Expected 3 requests. Got %d", requests)
+	}
+
+	if _, err := testClient.Resolver.TypeLink("Epic"); !errors.Is(err, ErrNameNotFound) {
+		t.Errorf("Expected ErrNameNotFound. Got %v", err)
+	}
+
+	testClient.Resolver.Reset()
+	testClient.Resolver.TypeLink("Task")
+	if requests != 4 {
+		t.Errorf("Expected types fetched again after Reset. Got %d requests", requests)
+	}
+}
+
+func TestResolver_FetchOutsideLock(t *testing.T) {
+	setup()
+	defer teardown()
+	types, err := os.ReadFile("./mocks/get/get-types.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	priorities, err := os.ReadFile("./mocks/get/get-priorities.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The types are only served once the priorities have been resolved
+	prioritiesResolved := make(chan struct{})
+	testMux.HandleFunc("/api/v3/types", func(w http.ResponseWriter, r *http.Request) {
+		<-prioritiesResolved
+		fmt.Fprint(w, string(types))
+	})
+	testMux.HandleFunc("/api/v3/priorities", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, string(priorities))
+	})
+
+	typeResolved := make(chan error, 1)
+	go func() {
+		_, err := testClient.Resolver.TypeLink("bug")
+		typeResolved <- err
+	}()
+	done := make(chan error, 1)
+	go func() {
+		// Give the type lookup time to start fetching
+		time.Sleep(20 * time.Millisecond)
+		_, err := testClient.Resolver.PriorityLink("high")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Error given: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the priority lookup not to wait for the types to be fetched")
+	}
+	close(prioritiesResolved)
+	if err := <-typeResolved; err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
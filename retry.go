@@ -0,0 +1,206 @@
+package openproject
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default values of RetryPolicy
+const (
+	defaultRetryMinBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// defaultRetryStatusCodes are the transient status codes retried unless RetryStatusCodes is set
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy configures how the Client retries requests failing with transient errors.
+// Retries wait an exponential backoff with jitter, or what the server asks for through the Retry-After header.
+// Only idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE) are retried unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one. Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the wait before the first retry, it doubles on every attempt. It will default to 500ms if 0.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the exponential backoff between attempts. It will default to 30s if 0.
+	// It does not shorten the wait asked for by Retry-After, which is honoured in full: when the request context
+	// expires before that wait is over, the failed response is returned right away instead of being retried.
+	MaxBackoff time.Duration
+
+	// RetryStatusCodes are the response status codes to retry. It will default to 429, 502, 503 and 504 if empty.
+	// Requests failing without response (network errors) are always retried.
+	RetryStatusCodes []int
+
+	// RetryNonIdempotent allows retrying POST and PATCH requests too.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy doing up to 3 attempts with the default backoff and status codes
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 3}
+}
+
+// WithRetryPolicy enables retries of failed requests following policy
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// send sends the request through the underlying http client, retrying it as the retry policy allows.
+// Every attempt is subject to the client rate limit.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	span := requestSpanFromContext(req.Context())
+	policy := c.retryPolicy
+	if !policy.allows(req) {
+		resp, err := c.do(req)
+		span.countBody(resp)
+		return resp, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+		if attempt > 1 {
+			span.retry()
+		}
+
+		resp, err := c.do(attemptReq)
+		if attempt >= policy.MaxAttempts || !policy.retryable(req, resp, err) {
+			span.countBody(resp)
+			return resp, err
+		}
+
+		wait := policy.backoff(attempt, resp)
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < wait {
+			// Retrying before the server allows it would fail again, give up with the response at hand
+			span.countBody(resp)
+			return resp, err
+		}
+		if resp != nil {
+			// Drain the body so the connection can be reused
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// rewindRequest returns the request to send on the given attempt, with a fresh copy of the body
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 {
+		return req, nil
+	}
+	attemptReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		attemptReq.Body = body
+	}
+	return attemptReq, nil
+}
+
+// allows reports whether the request may be retried at all
+func (p *RetryPolicy) allows(req *http.Request) bool {
+	if p == nil || p.MaxAttempts < 2 {
+		return false
+	}
+	// The body can not be sent again if it can not be rewound
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	}
+	return p.RetryNonIdempotent
+}
+
+// retryable reports whether the outcome of an attempt is a transient failure
+func (p *RetryPolicy) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// Cancelled or expired requests must not be retried
+		return req.Context().Err() == nil
+	}
+	statusCodes := p.RetryStatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = defaultRetryStatusCodes
+	}
+	for _, statusCode := range statusCodes {
+		if resp.StatusCode == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns how long to wait after the given failed attempt.
+// A Retry-After header is honoured as is, only the exponential backoff is capped by MaxBackoff.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+
+	wait := p.MinBackoff
+	if wait <= 0 {
+		wait = defaultRetryMinBackoff
+	}
+	for i := 1; i < attempt && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxBackoff {
+		wait = maxBackoff
+	}
+	// Equal jitter: half of the wait is fixed, the other half random
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
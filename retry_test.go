@@ -0,0 +1,183 @@
+package openproject
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// testRetryPolicy retries quickly so tests do not wait
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestClient_Do_RetryTransientErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+
+	c, _ := NewClient(nil, testServer.URL, WithRetryPolicy(testRetryPolicy()))
+	status, _, err := c.Status.Get("1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if status.Name != "New" {
+		t.Errorf("Expected status New. Got %s", status.Name)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts. Got %d", attempts)
+	}
+}
+
+func TestClient_Do_RetryGivesUp(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	testMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "Bad Gateway")
+	})
+
+	c, _ := NewClient(nil, testServer.URL, WithRetryPolicy(testRetryPolicy()))
+	req, _ := c.NewRequest("GET", "/", nil)
+	resp, err := c.Do(req, nil)
+	if err == nil {
+		t.Error("Expected HTTP 502 error.")
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts. Got %d", attempts)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "Bad Gateway" {
+		t.Errorf("Expected the last response body to be readable. Got %q", string(body))
+	}
+}
+
+func TestClient_Do_NoRetryForNonIdempotent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	testMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	c, _ := NewClient(nil, testServer.URL, WithRetryPolicy(testRetryPolicy()))
+	req, _ := c.NewRequest("POST", "/", &WorkPackage{Subject: "s"})
+	if _, err := c.Do(req, nil); err == nil {
+		t.Error("Expected HTTP 503 error.")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt. Got %d", attempts)
+	}
+}
+
+func TestClient_Do_RetryRewindsBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var bodies []string
+	testMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) < 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	policy := testRetryPolicy()
+	policy.RetryNonIdempotent = true
+	c, _ := NewClient(nil, testServer.URL, WithRetryPolicy(policy))
+
+	req, _ := c.NewRequest("POST", "/", &WorkPackage{Subject: "s"})
+	if _, err := c.Do(req, nil); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	req, _ = c.NewMultiPartRequest("POST", "/", bytes.NewBufferString("multipart content"))
+	if _, err := c.Do(req, nil); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	want := []string{`{"subject":"s"}` + "\n", `{"subject":"s"}` + "\n", "multipart content"}
+	if len(bodies) != len(want) {
+		t.Fatalf("Expected bodies %q. Got %q", want, bodies)
+	}
+	for i := range want {
+		if bodies[i] != want[i] {
+			t.Errorf("Expected body %q on request %d. Got %q", want[i], i, bodies[i])
+		}
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 5, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, max := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 5: time.Second} {
+		wait := policy.backoff(attempt, nil)
+		if wait < max/2 || wait > max {
+			t.Errorf("Expected backoff of attempt %d between %s and %s. Got %s", attempt, max/2, max, wait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "1")
+	if wait := policy.backoff(1, resp); wait != time.Second {
+		t.Errorf("Expected Retry-After to be honoured. Got %s", wait)
+	}
+	resp.Header.Set("Retry-After", "120")
+	if wait := policy.backoff(1, resp); wait != 120*time.Second {
+		t.Errorf("Expected Retry-After not to be capped by MaxBackoff. Got %s", wait)
+	}
+	resp.Header.Set("Retry-After", time.Now().Add(500*time.Millisecond).UTC().Format(http.TimeFormat))
+	if wait := policy.backoff(1, resp); wait > time.Second {
+		t.Errorf("Expected Retry-After date to be honoured. Got %s", wait)
+	}
+}
+
+func TestClient_Do_RetryAfterBeyondDeadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	c, _ := NewClient(nil, testServer.URL, WithRetryPolicy(testRetryPolicy()))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	_, resp, err := c.Status.GetWithContext(ctx, "1")
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected the 429 response to be returned. Got %v", resp)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt. Got %d", attempts)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected to give up right away. Took %s", elapsed)
+	}
+}
@@ -0,0 +1,65 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+)
+
+// RoleService handles the roles which can be granted to project members for the OpenProject instance / API.
+type RoleService struct {
+	client *Client
+}
+
+// Role is the object representing OpenProject roles
+type Role struct {
+	Type  string `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID    int    `json:"id,omitempty" structs:"id,omitempty"`
+	Name  string `json:"name,omitempty" structs:"name,omitempty"`
+	Links struct {
+		Self *OPGenericLink `json:"self,omitempty" structs:"self,omitempty"`
+	} `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// RoleList is the list of roles
+type RoleList struct {
+	Embedded struct {
+		Elements []Role `json:"elements,omitempty" structs:"elements,omitempty"`
+	} `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Total int `json:"total,omitempty" structs:"total,omitempty"`
+	Count int `json:"count,omitempty" structs:"count,omitempty"`
+}
+
+// Elements returns the roles of the list
+func (l *RoleList) Elements() []Role {
+	return l.Embedded.Elements
+}
+
+// GetWithContext gets a role from OpenProject using its ID
+func (s *RoleService) GetWithContext(ctx context.Context, roleID string) (*Role, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/roles/%s", roleID)
+	obj, resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Role), resp, err
+}
+
+// Get wraps GetWithContext using the background context.
+func (s *RoleService) Get(roleID string) (*Role, *Response, error) {
+	return s.GetWithContext(context.Background(), roleID)
+}
+
+// GetListWithContext retrieves every role of the instance, global roles included
+func (s *RoleService) GetListWithContext(ctx context.Context) (*RoleList, *Response, error) {
+	list := new(RoleList)
+	resp, err := getCollection(ctx, s.client, "api/v3/roles", list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return list, resp, nil
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *RoleService) GetList() (*RoleList, *Response, error) {
+	return s.GetListWithContext(context.Background())
+}
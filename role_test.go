@@ -0,0 +1,38 @@
+package openproject
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestRoleService_GetList(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/roles", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/api/v3/roles")
+		fmt.Fprint(w, `{"_type":"Collection","total":2,"count":2,"_embedded":{"elements":[
+			{"_type":"Role","id":3,"name":"Member","_links":{"self":{"href":"/api/v3/roles/3","title":"Member"}}},
+			{"_type":"Role","id":4,"name":"Reader","_links":{"self":{"href":"/api/v3/roles/4","title":"Reader"}}}]}}`)
+	})
+	testMux.HandleFunc("/api/v3/roles/4", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"_type":"Role","id":4,"name":"Reader"}`)
+	})
+
+	roles, _, err := testClient.Role.GetList()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(roles.Elements()) != 2 || roles.Elements()[0].Name != "Member" {
+		t.Errorf("Unexpected roles %+v", roles.Elements())
+	}
+	role, _, err := testClient.Role.Get("4")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if role.Name != "Reader" {
+		t.Errorf("Expected role Reader. Got %s", role.Name)
+	}
+}
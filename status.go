@@ -26,9 +26,15 @@ func (s *SearchResultStatus) TotalPage() int {
 
 func (s *SearchResultStatus) ConcatEmbed(status interface{}) {
 	s.lock.Lock()
+	defer s.lock.Unlock()
 	s.Embedded.Elements = append(s.Embedded.Elements, status.(*SearchResultStatus).Embedded.Elements...)
 }
 
+// Elements returns the statuses of the page
+func (s *SearchResultStatus) Elements() []Status {
+	return s.Embedded.Elements
+}
+
 // statusElements array wraps elemets within searchResultStatus
 type statusElements struct {
 	Elements []Status `json:"elements,omitempty" structs:"elements,omitempty"`
@@ -0,0 +1,321 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// TimeEntryService handles time entries for the OpenProject instance / API.
+type TimeEntryService struct {
+	client *Client
+}
+
+// TimeEntry is the object representing OpenProject time entries (logged time).
+type TimeEntry struct {
+	Type      string                `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID        int                   `json:"id,omitempty" structs:"id,omitempty"`
+	Comment   *OPGenericDescription `json:"comment,omitempty" structs:"comment,omitempty"`
+	SpentOn   *Date                 `json:"spentOn,omitempty" structs:"spentOn,omitempty"`
+	Hours     Duration              `json:"hours,omitempty" structs:"hours,omitempty"`
+	Ongoing   bool                  `json:"ongoing,omitempty" structs:"ongoing,omitempty"`
+	CreatedAt *Time                 `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	UpdatedAt *Time                 `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+	Links     TimeEntryLinks        `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// TimeEntryLinks are TimeEntry Links
+type TimeEntryLinks struct {
+	Self              *OPGenericLink `json:"self,omitempty" structs:"self,omitempty"`
+	UpdateImmediately *OPGenericLink `json:"updateImmediately,omitempty" structs:"updateImmediately,omitempty"`
+	Update            *OPGenericLink `json:"update,omitempty" structs:"update,omitempty"`
+	Delete            *OPGenericLink `json:"delete,omitempty" structs:"delete,omitempty"`
+	Schema            *OPGenericLink `json:"schema,omitempty" structs:"schema,omitempty"`
+	Project           *OPGenericLink `json:"project,omitempty" structs:"project,omitempty"`
+	WorkPackage       *OPGenericLink `json:"workPackage,omitempty" structs:"workPackage,omitempty"`
+	User              *OPGenericLink `json:"user,omitempty" structs:"user,omitempty"`
+	Activity          *OPGenericLink `json:"activity,omitempty" structs:"activity,omitempty"`
+}
+
+// TimeEntryPatch holds the changes of a time entry update. Nil fields are left unchanged, so an entry can be stopped
+// with &TimeEntryPatch{Ongoing: Ptr(false)} and its comment cleared with &TimeEntryPatch{Comment: &TextPatch{}}.
+type TimeEntryPatch struct {
+	Comment *TextPatch           `json:"comment,omitempty" structs:"comment,omitempty"`
+	SpentOn *Date                `json:"spentOn,omitempty" structs:"spentOn,omitempty"`
+	Hours   *Duration            `json:"hours,omitempty" structs:"hours,omitempty"`
+	Ongoing *bool                `json:"ongoing,omitempty" structs:"ongoing,omitempty"`
+	Links   *TimeEntryPatchLinks `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// TimeEntryPatchLinks are the links of a time entry to update. Nil fields are left unchanged.
+type TimeEntryPatchLinks struct {
+	WorkPackage *OPGenericLink `json:"workPackage,omitempty" structs:"workPackage,omitempty"`
+	User        *OPGenericLink `json:"user,omitempty" structs:"user,omitempty"`
+	Activity    *OPGenericLink `json:"activity,omitempty" structs:"activity,omitempty"`
+}
+
+// SearchResultTimeEntry represent a list of time entries
+type SearchResultTimeEntry struct {
+	Embedded timeEntryElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	PaginationParam
+}
+
+func (s *SearchResultTimeEntry) TotalPage() int {
+	return int(math.Ceil(float64(s.Total) / float64(s.PageSize)))
+}
+
+func (s *SearchResultTimeEntry) ConcatEmbed(entries interface{}) {
+	s.Embedded.Elements = append(s.Embedded.Elements, entries.(*SearchResultTimeEntry).Embedded.Elements...)
+}
+
+// Elements returns the time entries of the page
+func (s *SearchResultTimeEntry) Elements() []TimeEntry {
+	return s.Embedded.Elements
+}
+
+// timeEntryElements array wraps elements within SearchResultTimeEntry
+type timeEntryElements struct {
+	Elements []TimeEntry `json:"elements,omitempty" structs:"elements,omitempty"`
+}
+
+// TimeEntryActivity is the kind of work a time entry was spent on, like Development or Management
+type TimeEntryActivity struct {
+	Type     string `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID       int    `json:"id,omitempty" structs:"id,omitempty"`
+	Name     string `json:"name,omitempty" structs:"name,omitempty"`
+	Position int    `json:"position,omitempty" structs:"position,omitempty"`
+	Default  bool   `json:"default,omitempty" structs:"default,omitempty"`
+	Links    struct {
+		Self     *OPGenericLink  `json:"self,omitempty" structs:"self,omitempty"`
+		Projects []OPGenericLink `json:"projects,omitempty" structs:"projects,omitempty"`
+	} `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// timeEntryForm is the part of the time entry form listing the allowed activities
+type timeEntryForm struct {
+	Embedded struct {
+		Schema struct {
+			Activity struct {
+				Embedded struct {
+					AllowedValues []TimeEntryActivity `json:"allowedValues,omitempty"`
+				} `json:"_embedded,omitempty"`
+				Links struct {
+					AllowedValues []OPGenericLink `json:"allowedValues,omitempty"`
+				} `json:"_links,omitempty"`
+			} `json:"activity,omitempty"`
+		} `json:"schema,omitempty"`
+	} `json:"_embedded,omitempty"`
+}
+
+// FilterUser filters time entries by user, e.g. FilterUser(Me)
+func FilterUser(args ...interface{}) Filter {
+	return fieldFilter("user", args)
+}
+
+// FilterWorkPackage filters time entries by work-package, e.g. FilterWorkPackage(42)
+func FilterWorkPackage(args ...interface{}) Filter {
+	return fieldFilter("work_package", args)
+}
+
+// FilterActivity filters time entries by activity, e.g. FilterActivity(1)
+func FilterActivity(args ...interface{}) Filter {
+	return fieldFilter("activity", args)
+}
+
+// FilterSpentBetween filters time entries spent between from and to, both included
+func FilterSpentBetween(from Date, to Date) Filter {
+	return NewFilter("spent_on", BetweenDates, from, to)
+}
+
+// GetWithContext gets a time entry from OpenProject using its ID
+func (s *TimeEntryService) GetWithContext(ctx context.Context, timeEntryID string) (*TimeEntry, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/time_entries/%s", timeEntryID)
+	obj, resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*TimeEntry), resp, err
+}
+
+// Get wraps GetWithContext using the background context.
+func (s *TimeEntryService) Get(timeEntryID string) (*TimeEntry, *Response, error) {
+	return s.GetWithContext(context.Background(), timeEntryID)
+}
+
+// GetListWithContext retrieves time entries with context.
+// They can be filtered by user, project, work-package, activity and date with FilterUser, FilterProject,
+// FilterWorkPackage, FilterActivity and FilterSpentBetween.
+func (s *TimeEntryService) GetListWithContext(ctx context.Context, options *FilterOptions, offset int, pageSize int) (*SearchResultTimeEntry, *Response, error) {
+	apiEndpoint := "api/v3/time_entries"
+	obj, resp, err := GetListWithContext(ctx, s, apiEndpoint, options, offset, pageSize)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*SearchResultTimeEntry), resp, err
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *TimeEntryService) GetList(options *FilterOptions, offset int, pageSize int) (*SearchResultTimeEntry, *Response, error) {
+	return s.GetListWithContext(context.Background(), options, offset, pageSize)
+}
+
+// CreateWithContext logs time. The work-package (or project) and the activity are given in the links of the entry.
+func (s *TimeEntryService) CreateWithContext(ctx context.Context, entry *TimeEntry) (*TimeEntry, *Response, error) {
+	apiEndpoint := "api/v3/time_entries"
+	obj, resp, err := CreateWithContext(ctx, entry, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*TimeEntry), resp, err
+}
+
+// Create wraps CreateWithContext using the background context.
+func (s *TimeEntryService) Create(entry *TimeEntry) (*TimeEntry, *Response, error) {
+	return s.CreateWithContext(context.Background(), entry)
+}
+
+// UpdateWithContext updates a time entry. Only the fields set in patch are sent.
+func (s *TimeEntryService) UpdateWithContext(ctx context.Context, timeEntryID string, patch *TimeEntryPatch) (*TimeEntry, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/time_entries/%s", timeEntryID)
+	obj, resp, err := UpdateWithContext(ctx, patch, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*TimeEntry), resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *TimeEntryService) Update(timeEntryID string, patch *TimeEntryPatch) (*TimeEntry, *Response, error) {
+	return s.UpdateWithContext(context.Background(), timeEntryID, patch)
+}
+
+// DeleteWithContext deletes a time entry
+func (s *TimeEntryService) DeleteWithContext(ctx context.Context, timeEntryID string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/time_entries/%s", timeEntryID)
+	return DeleteWithContext(ctx, s, apiEndpoint)
+}
+
+// Delete wraps DeleteWithContext using the background context.
+func (s *TimeEntryService) Delete(timeEntryID string) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), timeEntryID)
+}
+
+// GetActivityWithContext gets a time entry activity using its ID
+func (s *TimeEntryService) GetActivityWithContext(ctx context.Context, activityID string) (*TimeEntryActivity, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/time_entries/activities/%s", activityID)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	activity := new(TimeEntryActivity)
+	resp, err := s.client.Do(req, activity)
+	if err != nil {
+		return nil, resp, NewOpenProjectError(resp, err)
+	}
+	return activity, resp, nil
+}
+
+// GetActivity wraps GetActivityWithContext using the background context.
+func (s *TimeEntryService) GetActivity(activityID string) (*TimeEntryActivity, *Response, error) {
+	return s.GetActivityWithContext(context.Background(), activityID)
+}
+
+// GetActivitiesWithContext lists the activities time can be logged on, in the given project if projectID is not empty.
+// The API has no activity collection, they are read from the time entry form.
+func (s *TimeEntryService) GetActivitiesWithContext(ctx context.Context, projectID string) ([]TimeEntryActivity, *Response, error) {
+	payload := &TimeEntry{}
+	if projectID != "" {
+		payload.Links.Project = &OPGenericLink{Href: fmt.Sprintf("/api/v3/projects/%s", projectID)}
+	}
+	req, err := s.client.NewRequestWithContext(ctx, "POST", "api/v3/time_entries/form", payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	form := new(timeEntryForm)
+	resp, err := s.client.Do(req, form)
+	if err != nil {
+		return nil, resp, NewOpenProjectError(resp, err)
+	}
+
+	activity := form.Embedded.Schema.Activity
+	if len(activity.Embedded.AllowedValues) > 0 {
+		return activity.Embedded.AllowedValues, resp, nil
+	}
+	activities := make([]TimeEntryActivity, 0, len(activity.Links.AllowedValues))
+	for _, link := range activity.Links.AllowedValues {
+		id, _ := strconv.Atoi(lastPathSegment(link.Href))
+		entry := TimeEntryActivity{ID: id, Name: link.Title}
+		entry.Links.Self = &OPGenericLink{Href: link.Href, Title: link.Title}
+		activities = append(activities, entry)
+	}
+	return activities, resp, nil
+}
+
+// GetActivities wraps GetActivitiesWithContext using the background context.
+func (s *TimeEntryService) GetActivities(projectID string) ([]TimeEntryActivity, *Response, error) {
+	return s.GetActivitiesWithContext(context.Background(), projectID)
+}
+
+// TimesheetDimension is a dimension time entries can be aggregated by
+type TimesheetDimension int
+
+const (
+	// ByUser aggregates the time spent per user
+	ByUser TimesheetDimension = iota
+	// ByProject aggregates the time spent per project
+	ByProject
+	// ByWeek aggregates the time spent per ISO week
+	ByWeek
+)
+
+// TimesheetKey identifies an aggregate of time entries.
+// Only the fields of the dimensions aggregated by are set.
+type TimesheetKey struct {
+	// User href and name
+	User OPGenericLink
+	// Project href and name
+	Project OPGenericLink
+	// Week is the ISO week the time was spent on, like 2023-W05
+	Week string
+}
+
+// AggregateHours sums the time spent by the entries per combination of the given dimensions, e.g. per user and week.
+// Usage case:
+//
+//	pager := NewPager[TimeEntry](WithFilter(opt, client.TimeEntry.GetListWithContext))
+//	... collect the entries ...
+//	hours := AggregateHours(entries, ByUser, ByWeek)
+func AggregateHours(entries []TimeEntry, dimensions ...TimesheetDimension) map[TimesheetKey]time.Duration {
+	result := make(map[TimesheetKey]time.Duration)
+	for i := range entries {
+		entry := &entries[i]
+		var key TimesheetKey
+		for _, dimension := range dimensions {
+			switch dimension {
+			case ByUser:
+				key.User = linkKey(entry.Links.User)
+			case ByProject:
+				key.Project = linkKey(entry.Links.Project)
+			case ByWeek:
+				if entry.SpentOn != nil {
+					year, week := entry.SpentOn.Time().ISOWeek()
+					key.Week = fmt.Sprintf("%04d-W%02d", year, week)
+				}
+			}
+		}
+		result[key] += entry.Hours.Duration()
+	}
+	return result
+}
+
+// linkKey returns the href and title of a link, to be used as aggregation key
+func linkKey(link *OPGenericLink) OPGenericLink {
+	if link == nil {
+		return OPGenericLink{}
+	}
+	return OPGenericLink{Href: link.Href, Title: link.Title}
+}
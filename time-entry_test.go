@@ -0,0 +1,216 @@
+package openproject
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestTimeEntryService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/time_entries/42"
+	raw, err := os.ReadFile("./mocks/get/get-time-entry.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, testAPIEdpoint)
+
+		fmt.Fprint(w, string(raw))
+	})
+
+	entry, _, err := testClient.TimeEntry.Get("42")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if entry.Comment.Raw != "API review" {
+		t.Errorf("Expected comment \"API review\". Got %q", entry.Comment.Raw)
+	}
+	if spent := entry.Hours.Duration(); spent != 150*time.Minute {
+		t.Errorf("Expected 2h30m spent. Got %s", spent)
+	}
+	if entry.Links.Activity.Title != "Development" {
+		t.Errorf("Expected activity Development. Got %q", entry.Links.Activity.Title)
+	}
+}
+
+func TestTimeEntryService_GetList(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/time_entries"
+	raw, err := os.ReadFile("./mocks/get/get-time-entries-filtered.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, testAPIEdpoint)
+
+		want := `[{"user":{"operator":"=","values":["me"]}},{"spent_on":{"operator":"<>d","values":["2023-01-30","2023-02-12"]}}]`
+		if got := r.URL.Query().Get("filters"); got != want {
+			t.Errorf("Expected filters %s. Got %s", want, got)
+		}
+		fmt.Fprint(w, string(raw))
+	})
+
+	from := Date(time.Date(2023, 1, 30, 0, 0, 0, 0, time.UTC))
+	to := Date(time.Date(2023, 2, 12, 0, 0, 0, 0, time.UTC))
+	opt := NewFilterOptions(FilterUser(Me), FilterSpentBetween(from, to))
+	entries, resp, err := testClient.TimeEntry.GetList(opt, 1, 20)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(entries.Elements()) != 3 {
+		t.Errorf("Expected 3 time entries. Got %d", len(entries.Elements()))
+	}
+	if resp.Total != 3 {
+		t.Errorf("Expected total 3 in response. Got %d", resp.Total)
+	}
+}
+
+func TestTimeEntryService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-time-entry.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/time_entries", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testRequestURL(t, r, "/api/v3/time_entries")
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		if payload["hours"] != "PT2H30M" || payload["spentOn"] != "2023-01-30" {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, string(raw))
+	})
+
+	spentOn := Date(time.Date(2023, 1, 30, 0, 0, 0, 0, time.UTC))
+	entry := &TimeEntry{
+		Comment: &OPGenericDescription{Raw: "API review"},
+		SpentOn: &spentOn,
+		Hours:   Duration(150 * time.Minute),
+		Links: TimeEntryLinks{
+			WorkPackage: &OPGenericLink{Href: "/api/v3/work_packages/36"},
+			Activity:    &OPGenericLink{Href: "/api/v3/time_entries/activities/1"},
+		},
+	}
+	created, _, err := testClient.TimeEntry.Create(entry)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if created.ID != 42 {
+		t.Errorf("Expected time entry 42. Got %d", created.ID)
+	}
+}
+
+func TestTimeEntryService_UpdateDelete(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-time-entry.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/time_entries/42", func(w http.ResponseWriter, r *http.Request) {
+		testRequestURL(t, r, "/api/v3/time_entries/42")
+		switch r.Method {
+		case "PATCH":
+			body, _ := io.ReadAll(r.Body)
+			if want := `{"comment":{"raw":""},"hours":"PT2H30M","ongoing":false}` + "\n"; string(body) != want {
+				t.Errorf("Expected body %s. Got %s", want, body)
+			}
+			fmt.Fprint(w, string(raw))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	if _, _, err := testClient.TimeEntry.Update("42", &TimeEntryPatch{
+		Comment: &TextPatch{},
+		Hours:   Ptr(Duration(150 * time.Minute)),
+		Ongoing: Ptr(false),
+	}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	resp, err := testClient.TimeEntry.Delete("42")
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected status 204. Got %d", resp.StatusCode)
+	}
+}
+
+func TestTimeEntryService_GetActivities(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/post/post-time-entry-form.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/time_entries/form", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var payload TimeEntry
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		if payload.Links.Project == nil || payload.Links.Project.Href != "/api/v3/projects/3" {
+			t.Errorf("Expected project link in form payload. Got %+v", payload.Links)
+		}
+		fmt.Fprint(w, string(raw))
+	})
+
+	activities, _, err := testClient.TimeEntry.GetActivities("3")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(activities) != 2 || activities[1].ID != 3 || activities[1].Name != "Development" {
+		t.Errorf("Unexpected activities %+v", activities)
+	}
+}
+
+func TestAggregateHours(t *testing.T) {
+	raw, err := os.ReadFile("./mocks/get/get-time-entries-filtered.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result SearchResultTimeEntry
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	byWeek := AggregateHours(result.Elements(), ByWeek)
+	want := map[TimesheetKey]time.Duration{
+		{Week: "2023-W05"}: 3*time.Hour + 30*time.Minute,
+		{Week: "2023-W06"}: 45 * time.Minute,
+	}
+	if fmt.Sprint(byWeek) != fmt.Sprint(want) {
+		t.Errorf("Expected %v. Got %v", want, byWeek)
+	}
+
+	byProject := AggregateHours(result.Elements(), ByUser, ByProject)
+	demo := TimesheetKey{
+		User:    OPGenericLink{Href: "/api/v3/users/5", Title: "Jane Doe"},
+		Project: OPGenericLink{Href: "/api/v3/projects/3", Title: "Demo project"},
+	}
+	if len(byProject) != 2 || byProject[demo] != 3*time.Hour+15*time.Minute {
+		t.Errorf("Unexpected aggregation %v", byProject)
+	}
+
+	if err := json.Unmarshal([]byte(`{"hours":"2 hours"}`), new(TimeEntry)); err == nil {
+		t.Error("Expected error on invalid hours")
+	}
+}
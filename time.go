@@ -0,0 +1,134 @@
+package openproject
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// dateLayout is the layout of OpenProject dates
+const dateLayout = "2006-01-02"
+
+// Time represents the Time definition of OpenProject as a time.Time of go.
+// It is read from RFC 3339 timestamps with or without fractional seconds and with any offset.
+// A zero Time is sent as null.
+type Time time.Time
+
+// Time returns t as a time.Time
+func (t Time) Time() time.Time {
+	return time.Time(t)
+}
+
+// IsZero reports whether t is the zero time, e.g. a null timestamp
+func (t Time) IsZero() bool {
+	return time.Time(t).IsZero()
+}
+
+// Equal compares time
+func (t Time) Equal(u Time) bool {
+	return time.Time(t).Equal(time.Time(u))
+}
+
+// String returns t in RFC 3339 format
+func (t Time) String() string {
+	return time.Time(t).Format(time.RFC3339Nano)
+}
+
+// UnmarshalJSON will transform the OpenProject time into a time.Time
+// during the transformation of the OpenProject JSON response
+func (t *Time) UnmarshalJSON(b []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(b) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*t = Time{}
+		return nil
+	}
+	// RFC3339Nano parses timestamps with and without fractional seconds
+	ti, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return err
+	}
+	*t = Time(ti)
+	return nil
+}
+
+// MarshalJSON will transform the time.Time into a OpenProject time
+// during the creation of a OpenProject request
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(time.Time(t).UTC().Format(time.RFC3339Nano))
+}
+
+// Date represents the Date definition of OpenProject as a time.Time of go.
+// A zero Date is sent as null.
+type Date time.Time
+
+// NewDate returns the Date of the given day
+func NewDate(year int, month time.Month, day int) Date {
+	return Date(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// Time returns d as a time.Time, at midnight UTC for dates read from OpenProject
+func (d Date) Time() time.Time {
+	return time.Time(d)
+}
+
+// IsZero reports whether d is the zero date, e.g. a null date
+func (d Date) IsZero() bool {
+	return time.Time(d).IsZero()
+}
+
+// Equal reports whether d and u are the same day
+func (d Date) Equal(u Date) bool {
+	return d.String() == u.String()
+}
+
+// String returns d in 2006-01-02 format
+func (d Date) String() string {
+	return time.Time(d).Format(dateLayout)
+}
+
+// UnmarshalJSON will transform the OpenProject date into a time.Time
+// during the transformation of the OpenProject JSON response
+func (d *Date) UnmarshalJSON(b []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(b) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*d = Date{}
+		return nil
+	}
+	ti, err := time.Parse(dateLayout, s)
+	if err != nil {
+		// Some dates are sent as timestamps, only their day is kept
+		ts, tsErr := time.Parse(time.RFC3339Nano, s)
+		if tsErr != nil {
+			return err
+		}
+		ti = time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	*d = Date(ti)
+	return nil
+}
+
+// MarshalJSON will transform the Date object into a short
+// date string as OpenProject expects during the creation of a
+// OpenProject request
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
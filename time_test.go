@@ -0,0 +1,78 @@
+package openproject
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	want := time.Date(2021, 2, 18, 10, 10, 46, 0, time.UTC)
+	tests := map[string]time.Time{
+		`"2021-02-18T10:10:46Z"`:           want,
+		`"2021-02-18T10:10:46.123Z"`:       want.Add(123 * time.Millisecond),
+		`"2021-02-18T10:10:46.123456789Z"`: want.Add(123456789),
+		`"2021-02-18T11:10:46+01:00"`:      want,
+		`"2021-02-18T10:10:46+00:00"`:      want,
+		`""`:                               {},
+	}
+	for in, expected := range tests {
+		var got Time
+		if err := json.Unmarshal([]byte(in), &got); err != nil {
+			t.Errorf("Unmarshal(%s): %s", in, err)
+		} else if !got.Time().Equal(expected) {
+			t.Errorf("Unmarshal(%s) = %s, want %s", in, got, expected)
+		}
+	}
+
+	var activity Activity
+	if err := json.Unmarshal([]byte(`{"createdAt":null}`), &activity); err != nil || activity.CreatedAt != nil {
+		t.Errorf("Expected null createdAt to be nil. Got %v (%v)", activity.CreatedAt, err)
+	}
+	if err := json.Unmarshal([]byte(`"18/02/2021"`), new(Time)); err == nil {
+		t.Error("Expected error on invalid time")
+	}
+}
+
+func TestTime_MarshalJSON(t *testing.T) {
+	offset := time.FixedZone("CET", 3600)
+	tests := map[Time]string{
+		{}: `null`,
+		Time(time.Date(2021, 2, 18, 11, 10, 46, 0, offset)):     `"2021-02-18T10:10:46Z"`,
+		Time(time.Date(2021, 2, 18, 10, 10, 46, 5e8, time.UTC)): `"2021-02-18T10:10:46.5Z"`,
+	}
+	for in, want := range tests {
+		got, err := json.Marshal(in)
+		if err != nil || string(got) != want {
+			t.Errorf("Marshal(%s) = %s (%v), want %s", in.Time(), got, err, want)
+		}
+	}
+}
+
+func TestDate_JSON(t *testing.T) {
+	tests := map[string]Date{
+		`"2023-01-30"`:           NewDate(2023, time.January, 30),
+		`"2023-01-30T23:30:00Z"`: NewDate(2023, time.January, 30),
+		`""`:                     {},
+	}
+	for in, want := range tests {
+		var got Date
+		if err := json.Unmarshal([]byte(in), &got); err != nil {
+			t.Errorf("Unmarshal(%s): %s", in, err)
+		} else if !got.Equal(want) || got.IsZero() != want.IsZero() {
+			t.Errorf("Unmarshal(%s) = %s, want %s", in, got, want)
+		}
+	}
+
+	wp := WorkPackage{StartDate: &Date{}, DueDate: func() *Date { d := NewDate(2023, time.February, 3); return &d }()}
+	got, err := json.Marshal(&wp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"startDate":null,"dueDate":"2023-02-03"}`; string(got) != want {
+		t.Errorf("Expected %s. Got %s", want, got)
+	}
+	if wp.DueDate.Time().Weekday() != time.Friday {
+		t.Errorf("Expected Friday. Got %s", wp.DueDate.Time().Weekday())
+	}
+}
@@ -0,0 +1,99 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+)
+
+// TypeService handles work-package types (Task, Bug, Milestone...) for the OpenProject instance / API.
+type TypeService struct {
+	client *Client
+}
+
+// Type is the object representing OpenProject work-package types
+type Type struct {
+	Type        string `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID          int    `json:"id,omitempty" structs:"id,omitempty"`
+	Name        string `json:"name,omitempty" structs:"name,omitempty"`
+	Color       string `json:"color,omitempty" structs:"color,omitempty"`
+	Position    int    `json:"position,omitempty" structs:"position,omitempty"`
+	IsDefault   bool   `json:"isDefault,omitempty" structs:"isDefault,omitempty"`
+	IsMilestone bool   `json:"isMilestone,omitempty" structs:"isMilestone,omitempty"`
+	CreatedAt   *Time  `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	UpdatedAt   *Time  `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+	Links       struct {
+		Self *OPGenericLink `json:"self,omitempty" structs:"self,omitempty"`
+	} `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// TypeList is the list of types
+type TypeList struct {
+	Embedded struct {
+		Elements []Type `json:"elements,omitempty" structs:"elements,omitempty"`
+	} `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Total int `json:"total,omitempty" structs:"total,omitempty"`
+	Count int `json:"count,omitempty" structs:"count,omitempty"`
+}
+
+// Elements returns the types of the list
+func (l *TypeList) Elements() []Type {
+	return l.Embedded.Elements
+}
+
+// GetWithContext gets a type from OpenProject using its ID
+func (s *TypeService) GetWithContext(ctx context.Context, typeID string) (*Type, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/types/%s", typeID)
+	obj, resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Type), resp, err
+}
+
+// Get wraps GetWithContext using the background context.
+func (s *TypeService) Get(typeID string) (*Type, *Response, error) {
+	return s.GetWithContext(context.Background(), typeID)
+}
+
+// GetListWithContext retrieves every type of the instance
+func (s *TypeService) GetListWithContext(ctx context.Context) (*TypeList, *Response, error) {
+	list := new(TypeList)
+	resp, err := getCollection(ctx, s.client, "api/v3/types", list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return list, resp, nil
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *TypeService) GetList() (*TypeList, *Response, error) {
+	return s.GetListWithContext(context.Background())
+}
+
+// GetByProjectWithContext retrieves the types enabled in a project
+func (s *TypeService) GetByProjectWithContext(ctx context.Context, projectID string) (*TypeList, *Response, error) {
+	list := new(TypeList)
+	resp, err := getCollection(ctx, s.client, fmt.Sprintf("api/v3/projects/%s/types", projectID), list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return list, resp, nil
+}
+
+// GetByProject wraps GetByProjectWithContext using the background context.
+func (s *TypeService) GetByProject(projectID string) (*TypeList, *Response, error) {
+	return s.GetByProjectWithContext(context.Background(), projectID)
+}
+
+// getCollection retrieves a collection which is not paginated, like types, priorities or the relations of a work-package
+func getCollection(ctx context.Context, client *Client, apiEndpoint string, list interface{}) (*Response, error) {
+	req, err := client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req, list)
+	if err != nil {
+		return resp, NewOpenProjectError(resp, err)
+	}
+	return resp, nil
+}
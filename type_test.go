@@ -0,0 +1,57 @@
+package openproject
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestTypeService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/types/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/api/v3/types/2")
+		fmt.Fprint(w, `{"_type":"Type","id":2,"name":"Milestone","isMilestone":true}`)
+	})
+
+	wpType, _, err := testClient.Type.Get("2")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if wpType.Name != "Milestone" || !wpType.IsMilestone {
+		t.Errorf("Unexpected type %+v", wpType)
+	}
+}
+
+func TestTypeService_GetList(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-types.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	for _, endpoint := range []string{"/api/v3/types", "/api/v3/projects/demo-project/types"} {
+		testMux.HandleFunc(endpoint, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, string(raw))
+		})
+	}
+
+	types, _, err := testClient.Type.GetList()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(types.Elements()) != 3 || types.Elements()[2].Name != "Bug" {
+		t.Errorf("Unexpected types %+v", types.Elements())
+	}
+
+	types, _, err = testClient.Type.GetByProject("demo-project")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if types.Total != 3 {
+		t.Errorf("Expected 3 types. Got %d", types.Total)
+	}
+}
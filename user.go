@@ -54,6 +54,11 @@ func (s *SearchResultUser) ConcatEmbed(users interface{}) {
 	s.Embedded.Elements = append(s.Embedded.Elements, users.(*SearchResultUser).Embedded.Elements...)
 }
 
+// Elements returns the users of the page
+func (s *SearchResultUser) Elements() []User {
+	return s.Embedded.Elements
+}
+
 // searchEmbeddedUser wraps embedded fields of User object
 type searchEmbeddedUser struct {
 	Elements []User `json:"elements" structs:"elements"`
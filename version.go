@@ -0,0 +1,346 @@
+package openproject
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// VersionService handles versions (milestones, sprints, releases) for the OpenProject instance / API.
+type VersionService struct {
+	client *Client
+}
+
+// VersionStatus is the status of a version
+type VersionStatus string
+
+// Constants to represent OpenProject version statuses
+const (
+	// VersionOpen versions can be assigned to work-packages
+	VersionOpen VersionStatus = "open"
+	// VersionLocked versions can not be assigned to more work-packages
+	VersionLocked VersionStatus = "locked"
+	// VersionClosed versions are done
+	VersionClosed VersionStatus = "closed"
+)
+
+// VersionSharing tells which projects besides the defining one can use a version
+type VersionSharing string
+
+// Constants to represent OpenProject version sharings
+const (
+	// SharingNone versions are only available in their project
+	SharingNone VersionSharing = "none"
+	// SharingDescendants versions are available in the subprojects
+	SharingDescendants VersionSharing = "descendants"
+	// SharingHierarchy versions are available in the ancestors and subprojects
+	SharingHierarchy VersionSharing = "hierarchy"
+	// SharingTree versions are available in the whole project tree
+	SharingTree VersionSharing = "tree"
+	// SharingSystem versions are available in every project
+	SharingSystem VersionSharing = "system"
+)
+
+// Version is the object representing OpenProject versions
+type Version struct {
+	Type        string                `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID          int                   `json:"id,omitempty" structs:"id,omitempty"`
+	Name        string                `json:"name,omitempty" structs:"name,omitempty"`
+	Description *OPGenericDescription `json:"description,omitempty" structs:"description,omitempty"`
+	StartDate   *Date                 `json:"startDate,omitempty" structs:"startDate,omitempty"`
+	EndDate     *Date                 `json:"endDate,omitempty" structs:"endDate,omitempty"`
+	Status      VersionStatus         `json:"status,omitempty" structs:"status,omitempty"`
+	Sharing     VersionSharing        `json:"sharing,omitempty" structs:"sharing,omitempty"`
+	CreatedAt   *Time                 `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	UpdatedAt   *Time                 `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+	// CustomFields are the values of the version custom fields by name, e.g. customField4
+	CustomFields map[string]interface{} `json:"-" structs:"-"`
+	Links        VersionLinks           `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// VersionLinks are Version Links
+type VersionLinks struct {
+	Self                *OPGenericLink `json:"self,omitempty" structs:"self,omitempty"`
+	Schema              *OPGenericLink `json:"schema,omitempty" structs:"schema,omitempty"`
+	Update              *OPGenericLink `json:"update,omitempty" structs:"update,omitempty"`
+	UpdateImmediately   *OPGenericLink `json:"updateImmediately,omitempty" structs:"updateImmediately,omitempty"`
+	Delete              *OPGenericLink `json:"delete,omitempty" structs:"delete,omitempty"`
+	DefiningProject     *OPGenericLink `json:"definingProject,omitempty" structs:"definingProject,omitempty"`
+	AvailableInProjects *OPGenericLink `json:"availableInProjects,omitempty" structs:"availableInProjects,omitempty"`
+}
+
+// customFieldPrefix is the prefix of the properties holding custom field values
+const customFieldPrefix = "customField"
+
+// UnmarshalJSON decodes the version and collects its custom fields
+func (v *Version) UnmarshalJSON(b []byte) error {
+	type version Version
+	var decoded version
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return err
+	}
+	var properties map[string]interface{}
+	if err := json.Unmarshal(b, &properties); err != nil {
+		return err
+	}
+	for name, value := range properties {
+		if strings.HasPrefix(name, customFieldPrefix) {
+			if decoded.CustomFields == nil {
+				decoded.CustomFields = make(map[string]interface{})
+			}
+			decoded.CustomFields[name] = value
+		}
+	}
+	*v = Version(decoded)
+	return nil
+}
+
+// MarshalJSON encodes the version with its custom fields as top level properties, as OpenProject expects them
+func (v Version) MarshalJSON() ([]byte, error) {
+	type version Version
+	data, err := json.Marshal(version(v))
+	if err != nil {
+		return nil, err
+	}
+	return withCustomFields(data, v.CustomFields)
+}
+
+// VersionPatch holds the changes of a version update. Nil fields are left unchanged, so the description can be
+// cleared with &VersionPatch{Description: &TextPatch{}} and a date with &VersionPatch{EndDate: &Date{}}.
+type VersionPatch struct {
+	Name        *string         `json:"name,omitempty" structs:"name,omitempty"`
+	Description *TextPatch      `json:"description,omitempty" structs:"description,omitempty"`
+	StartDate   *Date           `json:"startDate,omitempty" structs:"startDate,omitempty"`
+	EndDate     *Date           `json:"endDate,omitempty" structs:"endDate,omitempty"`
+	Status      *VersionStatus  `json:"status,omitempty" structs:"status,omitempty"`
+	Sharing     *VersionSharing `json:"sharing,omitempty" structs:"sharing,omitempty"`
+	// CustomFields are the custom fields to change by name, e.g. customField4. A nil value clears the field.
+	CustomFields map[string]interface{} `json:"-" structs:"-"`
+}
+
+// MarshalJSON encodes the patch with its custom fields as top level properties, as OpenProject expects them
+func (p VersionPatch) MarshalJSON() ([]byte, error) {
+	type versionPatch VersionPatch
+	data, err := json.Marshal(versionPatch(p))
+	if err != nil {
+		return nil, err
+	}
+	return withCustomFields(data, p.CustomFields)
+}
+
+// withCustomFields adds the custom fields to the properties of the JSON object data
+func withCustomFields(data []byte, customFields map[string]interface{}) ([]byte, error) {
+	if len(customFields) == 0 {
+		return data, nil
+	}
+	properties := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return nil, err
+	}
+	for name, value := range customFields {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		properties[name] = raw
+	}
+	return json.Marshal(properties)
+}
+
+// SearchResultVersion represent a list of versions
+type SearchResultVersion struct {
+	Embedded versionElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	PaginationParam
+}
+
+func (s *SearchResultVersion) TotalPage() int {
+	return int(math.Ceil(float64(s.Total) / float64(s.PageSize)))
+}
+
+func (s *SearchResultVersion) ConcatEmbed(versions interface{}) {
+	s.Embedded.Elements = append(s.Embedded.Elements, versions.(*SearchResultVersion).Embedded.Elements...)
+}
+
+// Elements returns the versions of the page
+func (s *SearchResultVersion) Elements() []Version {
+	return s.Embedded.Elements
+}
+
+// versionElements array wraps elements within SearchResultVersion
+type versionElements struct {
+	Elements []Version `json:"elements,omitempty" structs:"elements,omitempty"`
+}
+
+// FilterSharing filters versions by sharing, e.g. FilterSharing(SharingSystem)
+func FilterSharing(args ...interface{}) Filter {
+	return fieldFilter("sharing", args)
+}
+
+// GetWithContext gets a version from OpenProject using its ID
+func (s *VersionService) GetWithContext(ctx context.Context, versionID string) (*Version, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/versions/%s", versionID)
+	obj, resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Version), resp, err
+}
+
+// Get wraps GetWithContext using the background context.
+func (s *VersionService) Get(versionID string) (*Version, *Response, error) {
+	return s.GetWithContext(context.Background(), versionID)
+}
+
+// GetListWithContext retrieves the versions visible to the user, which can be filtered by sharing
+func (s *VersionService) GetListWithContext(ctx context.Context, options *FilterOptions, offset int, pageSize int) (*SearchResultVersion, *Response, error) {
+	apiEndpoint := "api/v3/versions"
+	obj, resp, err := GetListWithContext(ctx, s, apiEndpoint, options, offset, pageSize)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*SearchResultVersion), resp, err
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *VersionService) GetList(options *FilterOptions, offset int, pageSize int) (*SearchResultVersion, *Response, error) {
+	return s.GetListWithContext(context.Background(), options, offset, pageSize)
+}
+
+// GetByProjectWithContext retrieves the versions available in a project, shared ones included
+func (s *VersionService) GetByProjectWithContext(ctx context.Context, projectID string) ([]Version, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/projects/%s/versions", projectID)
+	versions := new(SearchResultVersion)
+	resp, err := getCollection(ctx, s.client, apiEndpoint, versions)
+	if err != nil {
+		return nil, resp, err
+	}
+	return versions.Elements(), resp, nil
+}
+
+// GetByProject wraps GetByProjectWithContext using the background context.
+func (s *VersionService) GetByProject(projectID string) ([]Version, *Response, error) {
+	return s.GetByProjectWithContext(context.Background(), projectID)
+}
+
+// CreateWithContext creates a version. The project defining it is given by version.Links.DefiningProject.
+func (s *VersionService) CreateWithContext(ctx context.Context, version *Version) (*Version, *Response, error) {
+	apiEndpoint := "api/v3/versions"
+	obj, resp, err := CreateWithContext(ctx, version, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Version), resp, err
+}
+
+// Create wraps CreateWithContext using the background context.
+func (s *VersionService) Create(version *Version) (*Version, *Response, error) {
+	return s.CreateWithContext(context.Background(), version)
+}
+
+// UpdateWithContext updates a version, e.g. closes it. Only the fields set in patch are sent.
+func (s *VersionService) UpdateWithContext(ctx context.Context, versionID string, patch *VersionPatch) (*Version, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/versions/%s", versionID)
+	obj, resp, err := UpdateWithContext(ctx, patch, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	return obj.(*Version), resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *VersionService) Update(versionID string, patch *VersionPatch) (*Version, *Response, error) {
+	return s.UpdateWithContext(context.Background(), versionID, patch)
+}
+
+// DeleteWithContext deletes a version. Versions still assigned to work-packages can not be deleted.
+func (s *VersionService) DeleteWithContext(ctx context.Context, versionID string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/versions/%s", versionID)
+	return DeleteWithContext(ctx, s, apiEndpoint)
+}
+
+// Delete wraps DeleteWithContext using the background context.
+func (s *VersionService) Delete(versionID string) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), versionID)
+}
+
+// VersionProgress sums up the work-packages of a version
+type VersionProgress struct {
+	Total  int
+	Open   int
+	Closed int
+
+	EstimatedTime time.Duration
+	SpentTime     time.Duration
+	RemainingTime time.Duration
+
+	// PercentageDone is the average percentage done of the work-packages weighted by their estimated time,
+	// or the plain average when nothing is estimated. Closed work-packages count as done.
+	PercentageDone float64
+}
+
+// GetWorkPackagesWithContext retrieves every work-package of a version, whatever its status, and sums up their progress.
+// Usage case:
+//
+//	wps, progress, err := client.Version.GetWorkPackages("12")
+//	for _, wp := range wps { ... move the open ones to the next version ... }
+func (s *VersionService) GetWorkPackagesWithContext(ctx context.Context, versionID string) ([]WorkPackage, *VersionProgress, error) {
+	closed := make(map[int]bool)
+	statuses := NewPager[Status](s.client.Status.GetListWithContext).Iterate(ctx)
+	defer statuses.Close()
+	for statuses.Next() {
+		status := statuses.Value()
+		closed[status.ID] = status.IsClosed
+	}
+	if err := statuses.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var workPackages []WorkPackage
+	progress := new(VersionProgress)
+	var weighted, sum float64
+	options := NewFilterOptions(FilterVersion(versionID), FilterStatus(All))
+	it := NewPager[WorkPackage](WithFilter(options, s.client.WorkPackage.GetListWithContext)).Iterate(ctx)
+	defer it.Close()
+	for it.Next() {
+		wp := it.Value()
+		workPackages = append(workPackages, wp)
+
+		done := float64(wp.PercentageDone)
+		progress.Total++
+		if wp.Links != nil && closed[linkID(wp.Links.Status)] {
+			progress.Closed++
+			done = 100
+		} else {
+			progress.Open++
+		}
+		if wp.EstimatedTime != nil {
+			progress.EstimatedTime += wp.EstimatedTime.Duration()
+			weighted += done * wp.EstimatedTime.Hours()
+		}
+		if wp.SpentTime != nil {
+			progress.SpentTime += wp.SpentTime.Duration()
+		}
+		if wp.RemainingTime != nil {
+			progress.RemainingTime += wp.RemainingTime.Duration()
+		}
+		sum += done
+	}
+	if err := it.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if progress.EstimatedTime > 0 {
+		progress.PercentageDone = weighted / progress.EstimatedTime.Hours()
+	} else if progress.Total > 0 {
+		progress.PercentageDone = sum / float64(progress.Total)
+	}
+	return workPackages, progress, nil
+}
+
+// GetWorkPackages wraps GetWorkPackagesWithContext using the background context.
+func (s *VersionService) GetWorkPackages(versionID string) ([]WorkPackage, *VersionProgress, error) {
+	return s.GetWorkPackagesWithContext(context.Background(), versionID)
+}
@@ -0,0 +1,153 @@
+package openproject
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVersionService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-version.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/versions/11", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/api/v3/versions/11")
+		fmt.Fprint(w, string(raw))
+	})
+
+	version, _, err := testClient.Version.Get("11")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if version.Status != VersionOpen || version.Sharing != SharingSystem {
+		t.Errorf("Unexpected status %s and sharing %s", version.Status, version.Sharing)
+	}
+	if version.EndDate.String() != "2023-02-10" || version.CustomFields["customField4"] != "Blue team" {
+		t.Errorf("Unexpected version %+v", version)
+	}
+}
+
+func TestVersionService_GetByProject(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-version.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/projects/3/versions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"_type":"Collection","total":1,"count":1,"_embedded":{"elements":[%s]}}`, raw)
+	})
+
+	versions, _, err := testClient.Version.GetByProject("3")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(versions) != 1 || versions[0].Name != "Sprint 12" {
+		t.Errorf("Unexpected versions %+v", versions)
+	}
+}
+
+func TestVersionService_CreateUpdateDelete(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-version.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/versions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		if body["name"] != "Sprint 12" || body["customField4"] != "Blue team" || body["startDate"] != "2023-01-30" {
+			t.Errorf("Unexpected body %v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, string(raw))
+	})
+	testMux.HandleFunc("/api/v3/versions/11", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PATCH":
+			body, _ := io.ReadAll(r.Body)
+			if want := `{"customField4":null,"endDate":null,"status":"closed"}` + "\n"; string(body) != want {
+				t.Errorf("Expected body %s. Got %s", want, body)
+			}
+			fmt.Fprint(w, strings.Replace(string(raw), `"status": "open"`, `"status": "closed"`, 1))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	startDate := NewDate(2023, time.January, 30)
+	version, _, err := testClient.Version.Create(&Version{
+		Name:         "Sprint 12",
+		StartDate:    &startDate,
+		CustomFields: map[string]interface{}{"customField4": "Blue team"},
+		Links:        VersionLinks{DefiningProject: &OPGenericLink{Href: "/api/v3/projects/3"}},
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	version, _, err = testClient.Version.Update(fmt.Sprint(version.ID), &VersionPatch{
+		EndDate:      &Date{},
+		Status:       Ptr(VersionClosed),
+		CustomFields: map[string]interface{}{"customField4": nil},
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if version.Status != VersionClosed {
+		t.Errorf("Expected closed version. Got %s", version.Status)
+	}
+	if _, err := testClient.Version.Delete("11"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestVersionService_GetWorkPackages(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/statuses", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total":2,"count":2,"pageSize":100,"offset":1,"_embedded":{"elements":[
+			{"id":1,"name":"New","isClosed":false},{"id":12,"name":"Closed","isClosed":true}]}}`)
+	})
+	testMux.HandleFunc("/api/v3/work_packages", func(w http.ResponseWriter, r *http.Request) {
+		want := `[{"version":{"operator":"=","values":["11"]}},{"status":{"operator":"*","values":[]}}]`
+		if got := r.URL.Query().Get("filters"); got != want {
+			t.Errorf("Expected filters %s. Got %s", want, got)
+		}
+		fmt.Fprint(w, `{"total":3,"count":3,"pageSize":100,"offset":1,"_embedded":{"elements":[
+			{"id":1,"estimatedTime":"PT6H","spentTime":"PT6H","percentageDone":90,"_links":{"status":{"href":"/api/v3/statuses/12"}}},
+			{"id":2,"estimatedTime":"PT2H","remainingTime":"PT1H","percentageDone":50,"_links":{"status":{"href":"/api/v3/statuses/1"}}},
+			{"id":3,"percentageDone":0,"_links":{"status":{"href":"/api/v3/statuses/1"}}}]}}`)
+	})
+
+	wps, progress, err := testClient.Version.GetWorkPackages("11")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(wps) != 3 || progress.Total != 3 || progress.Closed != 1 || progress.Open != 2 {
+		t.Errorf("Unexpected progress %+v", progress)
+	}
+	if progress.EstimatedTime != 8*time.Hour || progress.SpentTime != 6*time.Hour || progress.RemainingTime != time.Hour {
+		t.Errorf("Unexpected times %+v", progress)
+	}
+	// The closed work-package counts as done
+	if want := (100*6 + 50*2) / 8.0; math.Abs(progress.PercentageDone-want) > 1e-9 {
+		t.Errorf("Expected %f%% done. Got %f", want, progress.PercentageDone)
+	}
+}
@@ -0,0 +1,303 @@
+package openproject
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultTreeConcurrency is the number of work-packages whose children are fetched in parallel by default
+const defaultTreeConcurrency = 4
+
+// FilterParent filters the children of work-packages, e.g. FilterParent(42)
+func FilterParent(args ...interface{}) Filter {
+	return fieldFilter("parent", args)
+}
+
+// SetParentWithContext moves a work-package under parentID. An empty parentID makes it a root work-package.
+// The current lockVersion of the work-package is fetched first.
+func (s *WorkPackageService) SetParentWithContext(ctx context.Context, workpackageID string, parentID string) (*WorkPackage, *Response, error) {
+	current, resp, err := s.GetWithContext(ctx, workpackageID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	patch := &WorkPackagePatch{
+		LockVersion: current.LockVersion,
+		Links:       &WPLinks{Parent: resourceLink("work_packages", parentID)},
+	}
+	return s.UpdateWithContext(ctx, workpackageID, patch)
+}
+
+// SetParent wraps SetParentWithContext using the background context.
+func (s *WorkPackageService) SetParent(workpackageID string, parentID string) (*WorkPackage, *Response, error) {
+	return s.SetParentWithContext(context.Background(), workpackageID, parentID)
+}
+
+// RemoveParentWithContext makes a work-package a root work-package
+func (s *WorkPackageService) RemoveParentWithContext(ctx context.Context, workpackageID string) (*WorkPackage, *Response, error) {
+	return s.SetParentWithContext(ctx, workpackageID, "")
+}
+
+// RemoveParent wraps RemoveParentWithContext using the background context.
+func (s *WorkPackageService) RemoveParent(workpackageID string) (*WorkPackage, *Response, error) {
+	return s.RemoveParentWithContext(context.Background(), workpackageID)
+}
+
+// GetChildrenWithContext retrieves a page of the direct children of a work-package, whatever their status
+func (s *WorkPackageService) GetChildrenWithContext(ctx context.Context, parentID string, offset int, pageSize int) (*SearchResultWP, *Response, error) {
+	return s.GetListWithContext(ctx, NewFilterOptions(FilterParent(parentID)), offset, pageSize)
+}
+
+// GetChildren wraps GetChildrenWithContext using the background context.
+func (s *WorkPackageService) GetChildren(parentID string, offset int, pageSize int) (*SearchResultWP, *Response, error) {
+	return s.GetChildrenWithContext(context.Background(), parentID, offset, pageSize)
+}
+
+// ChildrenPager returns a Pager over every direct child of a work-package
+func (s *WorkPackageService) ChildrenPager(parentID string) *Pager[WorkPackage] {
+	return NewPager[WorkPackage](WithFilter(NewFilterOptions(FilterParent(parentID)), s.GetListWithContext))
+}
+
+// GetAncestorsWithContext retrieves the ancestors of a work-package, from the root down to its parent
+func (s *WorkPackageService) GetAncestorsWithContext(ctx context.Context, workpackageID string) ([]WorkPackage, *Response, error) {
+	wp, resp, err := s.GetWithContext(ctx, workpackageID)
+	if err != nil {
+		return nil, resp, err
+	}
+	if wp.Links == nil || len(wp.Links.Ancestors) == 0 {
+		return []WorkPackage{}, resp, nil
+	}
+
+	ids := make([]interface{}, 0, len(wp.Links.Ancestors))
+	for _, ancestor := range wp.Links.Ancestors {
+		ids = append(ids, Href(ancestor.Href))
+	}
+	result, resp, err := s.GetListWithContext(ctx, NewFilterOptions(FilterID(ids...)), 1, len(ids))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	// The list is sorted by the API, put it back in the order of the ancestors links
+	byID := make(map[int]WorkPackage, len(result.Elements()))
+	for _, ancestor := range result.Elements() {
+		byID[ancestor.ID] = ancestor
+	}
+	ancestors := make([]WorkPackage, 0, len(ids))
+	for _, link := range wp.Links.Ancestors {
+		if ancestor, ok := byID[linkID(&link)]; ok {
+			ancestors = append(ancestors, ancestor)
+		}
+	}
+	return ancestors, resp, nil
+}
+
+// GetAncestors wraps GetAncestorsWithContext using the background context.
+func (s *WorkPackageService) GetAncestors(workpackageID string) ([]WorkPackage, *Response, error) {
+	return s.GetAncestorsWithContext(context.Background(), workpackageID)
+}
+
+// TreeWalker fetches a work-package and all its descendants, level by level.
+// Usage case:
+//
+//	walker := client.WorkPackage.TreeWalker()
+//	walker.Concurrency = 8
+//	tree, err := walker.Walk(ctx, "42")
+//	fmt.Println(tree.Root.EstimatedTime(), tree.Root.PercentageDone())
+type TreeWalker struct {
+	// Concurrency is the maximum number of work-packages whose children are fetched in parallel.
+	// It will default to 4 if 0.
+	Concurrency int
+
+	// MaxDepth stops the walk below the given depth, the root being at depth 0. 0 means no limit.
+	MaxDepth int
+
+	// PageSize is the number of children requested per page. It will default to 100 if 0.
+	PageSize int
+
+	service *WorkPackageService
+}
+
+// TreeWalker returns a TreeWalker fetching work-packages with this service
+func (s *WorkPackageService) TreeWalker() *TreeWalker {
+	return &TreeWalker{service: s}
+}
+
+// Tree is an in-memory work-package hierarchy
+type Tree struct {
+	Root *TreeNode
+
+	nodes map[int]*TreeNode
+}
+
+// TreeNode is a work-package within a Tree
+type TreeNode struct {
+	WorkPackage WorkPackage
+	Parent      *TreeNode
+	Children    []*TreeNode
+	Depth       int
+}
+
+// Walk fetches the work-package rootID and its descendants breadth-first
+func (w *TreeWalker) Walk(ctx context.Context, rootID string) (*Tree, error) {
+	root, _, err := w.service.GetWithContext(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+	tree := &Tree{Root: &TreeNode{WorkPackage: *root}, nodes: make(map[int]*TreeNode)}
+	tree.nodes[root.ID] = tree.Root
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	level := []*TreeNode{tree.Root}
+	for len(level) > 0 && (w.MaxDepth == 0 || level[0].Depth < w.MaxDepth) {
+		if err := w.fetchChildren(ctx, level); err != nil {
+			return nil, err
+		}
+		var next []*TreeNode
+		for _, node := range level {
+			children := node.Children[:0]
+			for _, child := range node.Children {
+				// A work-package can not be its own descendant, but do not loop forever on inconsistent data.
+				// It is dropped from the children so that it is neither walked nor counted twice.
+				if _, seen := tree.nodes[child.WorkPackage.ID]; seen {
+					continue
+				}
+				tree.nodes[child.WorkPackage.ID] = child
+				children = append(children, child)
+				next = append(next, child)
+			}
+			node.Children = children
+		}
+		level = next
+	}
+	return tree, nil
+}
+
+// fetchChildren fills the children of the nodes, with at most Concurrency work-packages fetched in parallel
+func (w *TreeWalker) fetchChildren(ctx context.Context, nodes []*TreeNode) error {
+	concurrency := w.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultTreeConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	slots := make(chan struct{}, concurrency)
+	for _, node := range nodes {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(node *TreeNode) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if err := w.fetchNodeChildren(ctx, node); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(node)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// fetchNodeChildren fills the children of a single node, walking every page
+func (w *TreeWalker) fetchNodeChildren(ctx context.Context, node *TreeNode) error {
+	pager := w.service.ChildrenPager(strconv.Itoa(node.WorkPackage.ID))
+	pager.PageSize = w.PageSize
+	it := pager.Iterate(ctx)
+	defer it.Close()
+	for it.Next() {
+		node.Children = append(node.Children, &TreeNode{WorkPackage: it.Value(), Parent: node, Depth: node.Depth + 1})
+	}
+	return it.Err()
+}
+
+// Find returns the node of a work-package, nil if it is not in the tree
+func (t *Tree) Find(workpackageID int) *TreeNode {
+	return t.nodes[workpackageID]
+}
+
+// Len returns the number of work-packages of the tree
+func (t *Tree) Len() int {
+	return len(t.nodes)
+}
+
+// Walk calls fn for every node of the tree, parents before their children, breadth-first.
+// It stops at the first error returned by fn.
+func (t *Tree) Walk(fn func(node *TreeNode) error) error {
+	level := []*TreeNode{t.Root}
+	for len(level) > 0 {
+		var next []*TreeNode
+		for _, node := range level {
+			if err := fn(node); err != nil {
+				return err
+			}
+			next = append(next, node.Children...)
+		}
+		level = next
+	}
+	return nil
+}
+
+// IsLeaf reports whether the work-package has no children in the tree
+func (n *TreeNode) IsLeaf() bool {
+	return len(n.Children) == 0
+}
+
+// Path returns the nodes from the root of the tree down to n
+func (n *TreeNode) Path() []*TreeNode {
+	path := make([]*TreeNode, n.Depth+1)
+	for node := n; node != nil; node = node.Parent {
+		path[node.Depth] = node
+	}
+	return path
+}
+
+// EstimatedTime returns the estimated time of the work-package plus the one of all its descendants
+func (n *TreeNode) EstimatedTime() time.Duration {
+	var total time.Duration
+	if n.WorkPackage.EstimatedTime != nil {
+		total = n.WorkPackage.EstimatedTime.Duration()
+	}
+	for _, child := range n.Children {
+		total += child.EstimatedTime()
+	}
+	return total
+}
+
+// PercentageDone returns the progress of the work-package rolled up from its descendants.
+// Leaves report their own percentage done, parents the average of their children weighted by their estimated
+// time, or the plain average when nothing is estimated.
+func (n *TreeNode) PercentageDone() float64 {
+	if n.IsLeaf() {
+		return float64(n.WorkPackage.PercentageDone)
+	}
+	var weighted, sum float64
+	var estimated time.Duration
+	for _, child := range n.Children {
+		done := child.PercentageDone()
+		sum += done
+		childEstimate := child.EstimatedTime()
+		estimated += childEstimate
+		weighted += done * childEstimate.Hours()
+	}
+	if estimated > 0 {
+		return weighted / estimated.Hours()
+	}
+	return sum / float64(len(n.Children))
+}
@@ -0,0 +1,183 @@
+package openproject
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// hierarchyWorkPackages are the work-packages served by handleHierarchy: 1 is the parent of 2 and 3, 2 of 4
+var hierarchyWorkPackages = map[int]string{
+	1: `{"id":1,"subject":"Epic","lockVersion":3,"percentageDone":0,"_links":{}}`,
+	2: `{"id":2,"subject":"Feature","estimatedTime":"PT6H","percentageDone":0,"_links":{"parent":{"href":"/api/v3/work_packages/1"},"ancestors":[{"href":"/api/v3/work_packages/1"}]}}`,
+	3: `{"id":3,"subject":"Docs","estimatedTime":"PT2H","percentageDone":100,"_links":{"parent":{"href":"/api/v3/work_packages/1"},"ancestors":[{"href":"/api/v3/work_packages/1"}]}}`,
+	4: `{"id":4,"subject":"Task","estimatedTime":"PT6H","percentageDone":50,"_links":{"parent":{"href":"/api/v3/work_packages/2"},"ancestors":[{"href":"/api/v3/work_packages/1"},{"href":"/api/v3/work_packages/2"}]}}`,
+}
+
+var hierarchyChildren = map[string][]int{"1": {2, 3}, "2": {4}}
+
+// handleHierarchy serves hierarchyWorkPackages, their children lists and the lists filtered by ID
+func handleHierarchy(t *testing.T, requests *int32) {
+	testMux.HandleFunc("/api/v3/work_packages/", func(w http.ResponseWriter, r *http.Request) {
+		var id int
+		fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/api/v3/work_packages/"), "%d", &id)
+		fmt.Fprint(w, hierarchyWorkPackages[id])
+	})
+	testMux.HandleFunc("/api/v3/work_packages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		atomic.AddInt32(requests, 1)
+		var filters []map[string]filterJSON
+		if err := json.Unmarshal([]byte(r.URL.Query().Get("filters")), &filters); err != nil {
+			t.Error(err)
+		}
+		var ids []int
+		if filter, ok := filters[0]["parent"]; ok {
+			ids = hierarchyChildren[filter.Values[0]]
+		} else {
+			for _, value := range filters[0]["id"].Values {
+				var id int
+				fmt.Sscanf(value, "%d", &id)
+				ids = append([]int{id}, ids...)
+			}
+		}
+		elements := make([]string, 0, len(ids))
+		for _, id := range ids {
+			elements = append(elements, hierarchyWorkPackages[id])
+		}
+		fmt.Fprintf(w, `{"total":%d,"count":%d,"pageSize":100,"offset":1,"_embedded":{"elements":[%s]}}`,
+			len(ids), len(ids), strings.Join(elements, ","))
+	})
+}
+
+func TestWorkPackageService_SetParent(t *testing.T) {
+	setup()
+	defer teardown()
+	var bodies []string
+	testMux.HandleFunc("/api/v3/work_packages/3", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Error(err)
+			}
+			bodies = append(bodies, strings.TrimSpace(string(body)))
+		}
+		fmt.Fprint(w, `{"id":3,"lockVersion":7}`)
+	})
+
+	if _, _, err := testClient.WorkPackage.SetParent("3", "2"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := testClient.WorkPackage.RemoveParent("3"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	want := []string{
+		`{"lockVersion":7,"_links":{"parent":{"href":"/api/v3/work_packages/2"}}}`,
+		`{"lockVersion":7,"_links":{"parent":{"href":null}}}`,
+	}
+	if !reflect.DeepEqual(bodies, want) {
+		t.Errorf("Expected bodies %v. Got %v", want, bodies)
+	}
+}
+
+func TestWorkPackageService_GetAncestors(t *testing.T) {
+	setup()
+	defer teardown()
+	var requests int32
+	handleHierarchy(t, &requests)
+
+	ancestors, _, err := testClient.WorkPackage.GetAncestors("4")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(ancestors) != 2 || ancestors[0].ID != 1 || ancestors[1].ID != 2 {
+		t.Errorf("Expected ancestors 1 and 2. Got %+v", ancestors)
+	}
+
+	children, _, err := testClient.WorkPackage.GetChildren("1", 1, 100)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(children.Elements()) != 2 {
+		t.Errorf("Expected 2 children. Got %d", len(children.Elements()))
+	}
+}
+
+func TestTreeWalker_Walk(t *testing.T) {
+	setup()
+	defer teardown()
+	var requests int32
+	handleHierarchy(t, &requests)
+
+	walker := testClient.WorkPackage.TreeWalker()
+	walker.Concurrency = 2
+	tree, err := walker.Walk(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if tree.Len() != 4 || requests != 4 {
+		t.Errorf("Expected 4 work-packages fetched with 4 children lists. Got %d with %d", tree.Len(), requests)
+	}
+
+	var visited []int
+	tree.Walk(func(node *TreeNode) error {
+		visited = append(visited, node.WorkPackage.ID)
+		return nil
+	})
+	if !reflect.DeepEqual(visited, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected breadth-first order [1 2 3 4]. Got %v", visited)
+	}
+
+	task := tree.Find(4)
+	if task.Depth != 2 || task.Path()[1].WorkPackage.Subject != "Feature" {
+		t.Errorf("Unexpected position of work-package 4: depth %d", task.Depth)
+	}
+	if got := tree.Root.EstimatedTime(); got != 14*time.Hour {
+		t.Errorf("Expected 14h estimated. Got %s", got)
+	}
+	// Feature rolls up its half done task and weighs 12h, Docs are done and weigh 2h
+	if got := tree.Root.PercentageDone(); got != (50*12+100*2)/14.0 {
+		t.Errorf("Expected rolled up percentage done. Got %f", got)
+	}
+
+	walker.MaxDepth = 1
+	tree, err = walker.Walk(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if tree.Len() != 3 || !tree.Find(2).IsLeaf() {
+		t.Errorf("Expected the walk to stop at depth 1. Got %d work-packages", tree.Len())
+	}
+}
+
+func TestTreeWalker_Walk_InconsistentChildren(t *testing.T) {
+	setup()
+	defer teardown()
+	var requests int32
+	handleHierarchy(t, &requests)
+	// 4 is listed under both 2 and 3, and lists the root as its own child
+	defer func(children map[string][]int) { hierarchyChildren = children }(hierarchyChildren)
+	hierarchyChildren = map[string][]int{"1": {2, 3}, "2": {4}, "3": {4}, "4": {1}}
+
+	tree, err := testClient.WorkPackage.TreeWalker().Walk(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	visits := 0
+	tree.Walk(func(node *TreeNode) error {
+		visits++
+		return nil
+	})
+	if tree.Len() != 4 || visits != 4 {
+		t.Errorf("Expected every work-package once. Got %d work-packages, %d visits", tree.Len(), visits)
+	}
+	if got := tree.Root.EstimatedTime(); got != 14*time.Hour {
+		t.Errorf("Expected 14h estimated, without counting work-package 4 twice. Got %s", got)
+	}
+}
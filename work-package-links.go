@@ -0,0 +1,86 @@
+package openproject
+
+import "fmt"
+
+// links returns the links of the work-package, creating them if needed
+func (wp *WorkPackage) links() *WPLinks {
+	if wp.Links == nil {
+		wp.Links = new(WPLinks)
+	}
+	return wp.Links
+}
+
+// resourceLink returns the link to a resource of a collection, or a null link if id is empty
+func resourceLink(collection string, id string) *OPGenericLink {
+	if id == "" {
+		return NullLink()
+	}
+	return &OPGenericLink{Href: fmt.Sprintf("/api/v3/%s/%s", collection, id)}
+}
+
+// SetAssignee assigns the work-package to a user (or group), e.g. wp.SetAssignee("5").
+// An empty userID unassigns it.
+func (wp *WorkPackage) SetAssignee(userID string) *WorkPackage {
+	wp.links().Assignee = resourceLink("users", userID)
+	return wp
+}
+
+// SetResponsible sets the user (or group) accountable for the work-package. An empty userID removes it.
+func (wp *WorkPackage) SetResponsible(userID string) *WorkPackage {
+	wp.links().Responsible = resourceLink("users", userID)
+	return wp
+}
+
+// SetStatus sets the status of the work-package.
+// A work-package always has a status, so an empty statusID leaves it unchanged instead of sending a null link.
+func (wp *WorkPackage) SetStatus(statusID string) *WorkPackage {
+	if statusID != "" {
+		wp.links().Status = resourceLink("statuses", statusID)
+	}
+	return wp
+}
+
+// SetType sets the type of the work-package, see Resolver.TypeLink to find it by name.
+// A work-package always has a type, so an empty typeID leaves it unchanged.
+func (wp *WorkPackage) SetType(typeID string) *WorkPackage {
+	if typeID != "" {
+		wp.links().Type = resourceLink("types", typeID)
+	}
+	return wp
+}
+
+// SetPriority sets the priority of the work-package, see Resolver.PriorityLink to find it by name.
+// A work-package always has a priority, so an empty priorityID leaves it unchanged.
+func (wp *WorkPackage) SetPriority(priorityID string) *WorkPackage {
+	if priorityID != "" {
+		wp.links().Priority = resourceLink("priorities", priorityID)
+	}
+	return wp
+}
+
+// SetProject sets the project of the work-package, moving it on update.
+// A work-package always belongs to a project, so an empty projectID leaves it unchanged.
+func (wp *WorkPackage) SetProject(projectID string) *WorkPackage {
+	if projectID != "" {
+		wp.links().Project = resourceLink("projects", projectID)
+	}
+	return wp
+}
+
+// SetVersion sets the version of the work-package. An empty versionID removes it.
+func (wp *WorkPackage) SetVersion(versionID string) *WorkPackage {
+	wp.links().Version = resourceLink("versions", versionID)
+	return wp
+}
+
+// SetCategory sets the category of the work-package. An empty categoryID removes it.
+func (wp *WorkPackage) SetCategory(categoryID string) *WorkPackage {
+	wp.links().Category = resourceLink("categories", categoryID)
+	return wp
+}
+
+// SetParent sets the parent of the work-package. An empty parentID makes it a root work-package.
+func (wp *WorkPackage) SetParent(parentID string) *WorkPackage {
+	wp.links().Parent = resourceLink("work_packages", parentID)
+	return wp
+}
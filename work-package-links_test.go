@@ -0,0 +1,121 @@
+package openproject
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestWorkPackage_SetLinks(t *testing.T) {
+	wp := &WorkPackage{Subject: "Intake"}
+	wp.SetAssignee("5").SetResponsible("").SetStatus("1").SetType("7").SetPriority("9").
+		SetProject("demo-project").SetVersion("11").SetCategory("").SetParent("42")
+
+	data, err := json.Marshal(wp.Links)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"category":{"href":null},"type":{"href":"/api/v3/types/7"},"priority":{"href":"/api/v3/priorities/9"},` +
+		`"project":{"href":"/api/v3/projects/demo-project"},"status":{"href":"/api/v3/statuses/1"},` +
+		`"responsible":{"href":null},"assignee":{"href":"/api/v3/users/5"},"version":{"href":"/api/v3/versions/11"},` +
+		`"parent":{"href":"/api/v3/work_packages/42"}}`
+	if string(data) != want {
+		t.Errorf("Expected links %s. Got %s", want, data)
+	}
+	if wp.Links.Assignee.LinkID() != "5" || wp.Links.Project.LinkID() != "demo-project" || wp.Links.Category.LinkID() != "" {
+		t.Errorf("Unexpected link IDs %+v", wp.Links)
+	}
+
+	// Required links are never nulled
+	wp.SetStatus("").SetType("").SetPriority("").SetProject("")
+	if wp.Links.Status.LinkID() != "1" || wp.Links.Type.LinkID() != "7" || wp.Links.Priority.LinkID() != "9" ||
+		wp.Links.Project.LinkID() != "demo-project" {
+		t.Errorf("Expected required links to be left unchanged. Got %+v", wp.Links)
+	}
+	if links := new(WorkPackage).SetStatus("").Links; links != nil && links.Status != nil {
+		t.Errorf("Expected no status link. Got %+v", links.Status)
+	}
+}
+
+func TestOPGenericLink_JSON(t *testing.T) {
+	data, err := json.Marshal(OPGenericLink{Href: "/api/v3/users/5", Title: "Jane Doe"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"href":"/api/v3/users/5","title":"Jane Doe"}`; string(data) != want {
+		t.Errorf("Expected %s. Got %s", want, data)
+	}
+
+	var link OPGenericLink
+	if err := json.Unmarshal([]byte(`{"href":null,"title":"Nobody"}`), &link); err != nil {
+		t.Fatal(err)
+	}
+	if !link.IsNull() || link.Title != "Nobody" {
+		t.Errorf("Expected null link. Got %+v", link)
+	}
+	var missing *OPGenericLink
+	if !missing.IsNull() || missing.LinkID() != "" {
+		t.Error("Expected missing link to be null")
+	}
+}
+
+// Links held by value, like the ones of activities and attachments, are encoded as null links when empty
+func TestOPGenericLink_ValueFieldsRoundTrip(t *testing.T) {
+	var activity Activity
+	raw := `{"_type":"Activity::Comment","id":1,"_links":{"self":{"href":"/api/v3/activities/1"},` +
+		`"workPackage":{"href":"/api/v3/work_packages/36"},"user":{"href":"/api/v3/users/5"}}}`
+	if err := json.Unmarshal([]byte(raw), &activity); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(activity.Links)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"self":{"href":"/api/v3/activities/1"},"workPackage":{"href":"/api/v3/work_packages/36"},` +
+		`"user":{"href":"/api/v3/users/5"},"update":{"href":null}}`
+	if string(data) != want {
+		t.Errorf("Expected activity links %s. Got %s", want, data)
+	}
+	var back Activity
+	if err := json.Unmarshal([]byte(`{"_links":`+string(data)+`}`), &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Links != activity.Links {
+		t.Errorf("Expected activity links to round-trip. Got %+v", back.Links)
+	}
+
+	var attachment Attachment
+	if err := json.Unmarshal([]byte(`{"id":2,"_links":{"self":{"href":"/api/v3/attachments/2"}}}`), &attachment); err != nil {
+		t.Fatal(err)
+	}
+	data, err = json.Marshal(attachment.Links)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `{"self":{"href":"/api/v3/attachments/2"},"author":{"href":null},"container":{"href":null},` +
+		`"staticDownloadLocation":{"href":null},"downloadLocation":{"href":null}}`
+	if string(data) != want {
+		t.Errorf("Expected attachment links %s. Got %s", want, data)
+	}
+}
+
+func TestWorkPackageService_Update_Unassign(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/work_packages/36", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		var payload map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		if want := `{"assignee":{"href":null}}`; string(payload["_links"]) != want {
+			t.Errorf("Expected links %s. Got %s", want, payload["_links"])
+		}
+		w.Write([]byte(`{"id":36,"lockVersion":4}`))
+	})
+
+	patch := &WorkPackagePatch{LockVersion: 3, Links: &WPLinks{Assignee: NullLink()}}
+	if _, _, err := testClient.WorkPackage.Update("36", patch); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
@@ -0,0 +1,72 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListWatchersWithContext retrieves the users watching a work-package
+func (s *WorkPackageService) ListWatchersWithContext(ctx context.Context, workpackageID string) ([]User, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/work_packages/%s/watchers", workpackageID)
+	return s.getUsers(ctx, apiEndpoint)
+}
+
+// ListWatchers wraps ListWatchersWithContext using the background context.
+func (s *WorkPackageService) ListWatchers(workpackageID string) ([]User, *Response, error) {
+	return s.ListWatchersWithContext(context.Background(), workpackageID)
+}
+
+// AvailableWatchersWithContext retrieves the users allowed to watch a work-package
+func (s *WorkPackageService) AvailableWatchersWithContext(ctx context.Context, workpackageID string) ([]User, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/work_packages/%s/available_watchers", workpackageID)
+	return s.getUsers(ctx, apiEndpoint)
+}
+
+// AvailableWatchers wraps AvailableWatchersWithContext using the background context.
+func (s *WorkPackageService) AvailableWatchers(workpackageID string) ([]User, *Response, error) {
+	return s.AvailableWatchersWithContext(context.Background(), workpackageID)
+}
+
+// AddWatcherWithContext makes a user watch a work-package. Adding a user already watching it is not an error.
+// userID can be Me to watch the work-package with the authenticated user.
+func (s *WorkPackageService) AddWatcherWithContext(ctx context.Context, workpackageID string, userID string) (*User, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/work_packages/%s/watchers", workpackageID)
+	payload := &WPActionPayload{User: &OPGenericLink{Href: fmt.Sprintf("/api/v3/users/%s", userID)}}
+	req, err := s.client.NewRequestWithContext(ctx, "POST", apiEndpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := new(User)
+	resp, err := s.client.Do(req, user)
+	if err != nil {
+		return nil, resp, NewOpenProjectError(resp, err)
+	}
+	return user, resp, nil
+}
+
+// AddWatcher wraps AddWatcherWithContext using the background context.
+func (s *WorkPackageService) AddWatcher(workpackageID string, userID string) (*User, *Response, error) {
+	return s.AddWatcherWithContext(context.Background(), workpackageID, userID)
+}
+
+// RemoveWatcherWithContext makes a user stop watching a work-package
+func (s *WorkPackageService) RemoveWatcherWithContext(ctx context.Context, workpackageID string, userID string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/work_packages/%s/watchers/%s", workpackageID, userID)
+	return DeleteWithContext(ctx, s, apiEndpoint)
+}
+
+// RemoveWatcher wraps RemoveWatcherWithContext using the background context.
+func (s *WorkPackageService) RemoveWatcher(workpackageID string, userID string) (*Response, error) {
+	return s.RemoveWatcherWithContext(context.Background(), workpackageID, userID)
+}
+
+// getUsers retrieves a collection of users which is not paginated
+func (s *WorkPackageService) getUsers(ctx context.Context, apiEndpoint string) ([]User, *Response, error) {
+	users := new(SearchResultUser)
+	resp, err := getCollection(ctx, s.client, apiEndpoint, users)
+	if err != nil {
+		return nil, resp, err
+	}
+	return users.Elements(), resp, nil
+}
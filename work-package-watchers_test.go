@@ -0,0 +1,96 @@
+package openproject
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestWorkPackageService_ListWatchers(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-workpackage-watchers.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	for _, endpoint := range []string{"/api/v3/work_packages/36/watchers", "/api/v3/work_packages/36/available_watchers"} {
+		testMux.HandleFunc(endpoint, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, string(raw))
+		})
+	}
+
+	watchers, _, err := testClient.WorkPackage.ListWatchers("36")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(watchers) != 2 || watchers[1].Login != "jdoe" {
+		t.Errorf("Expected watchers admin and jdoe. Got %+v", watchers)
+	}
+
+	available, _, err := testClient.WorkPackage.AvailableWatchers("36")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(available) != 2 {
+		t.Errorf("Expected 2 available watchers. Got %d", len(available))
+	}
+}
+
+func TestWorkPackageService_AddRemoveWatcher(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/work_packages/36/watchers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var payload WPActionPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		if payload.User == nil || payload.User.Href != "/api/v3/users/5" {
+			t.Errorf("Unexpected payload %+v", payload)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"_type":"User","id":5,"login":"jdoe"}`)
+	})
+	testMux.HandleFunc("/api/v3/work_packages/36/watchers/5", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	user, _, err := testClient.WorkPackage.AddWatcher("36", "5")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if user.ID != 5 {
+		t.Errorf("Expected user 5. Got %d", user.ID)
+	}
+	resp, err := testClient.WorkPackage.RemoveWatcher("36", "5")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected status 204. Got %d", resp.StatusCode)
+	}
+}
+
+func TestWPLinks_WatcherActions(t *testing.T) {
+	raw, err := os.ReadFile("./mocks/post/post-workpackage.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wp WorkPackage
+	if err := json.Unmarshal(raw, &wp); err != nil {
+		t.Fatal(err)
+	}
+	if add := wp.Links.AddWatcher; add == nil || !add.Templated || add.Payload.User.Href != "/api/v3/users/{user_id}" {
+		t.Errorf("Unexpected addWatcher link %+v", add)
+	}
+	if watch := wp.Links.Watch; watch == nil || watch.Method != "post" || watch.Payload.User.Href != "/api/v3/users/1" {
+		t.Errorf("Unexpected watch link %+v", watch)
+	}
+	if wp.Links.Unwatch != nil {
+		t.Errorf("Expected no unwatch link. Got %+v", wp.Links.Unwatch)
+	}
+}
@@ -3,12 +3,12 @@ package openproject
 import (
 	"context"
 	"fmt"
+	"github.com/pkg/errors"
 	"github.com/trivago/tgo/tcontainer"
 	"math"
-	"strings"
+	"net/http"
 
 	"net/url"
-	"time"
 )
 
 // WorkPackageService handles workpackages for the OpenProject instance / API.
@@ -16,55 +16,11 @@ type WorkPackageService struct {
 	client *Client
 }
 
-// UnmarshalJSON will transform the OpenProject time into a time.Time
-// during the transformation of the OpenProject JSON response
-func (t *Time) UnmarshalJSON(b []byte) error {
-	// Ignore null, like in the main JSON package.
-	if string(b) == "null" {
-		return nil
-	}
-	ti, err := time.Parse("\"2006-01-02T15:04:05Z\"", string(b))
-	if err != nil {
-		return err
-	}
-	*t = Time(ti)
-	return nil
-}
-
-// MarshalJSON will transform the time.Time into a OpenProject time
-// during the creation of a OpenProject request
-func (t Time) MarshalJSON() ([]byte, error) {
-	return []byte(time.Time(t).Format("\"2006-01-02T15:04:05Z\"")), nil
-}
-
-// UnmarshalJSON will transform the OpenProject date into a time.Time
-// during the transformation of the OpenProject JSON response
-func (t *Date) UnmarshalJSON(b []byte) error {
-	// Ignore null, like in the main JSON package.
-	if string(b) == "null" {
-		return nil
-	}
-	ti, err := time.Parse("\"2006-01-02\"", string(b))
-	if err != nil {
-		return err
-	}
-	*t = Date(ti)
-	return nil
-}
-
-// MarshalJSON will transform the Date object into a short
-// date string as OpenProject expects during the creation of a
-// OpenProject request
-func (t Date) MarshalJSON() ([]byte, error) {
-	time := time.Time(t)
-	return []byte(time.Format("\"2006-01-02\"")), nil
-}
-
 // WorkPackage represents an OpenProject ticket or issue
 type WorkPackage struct {
-	DerivedStartDate     interface{}           `json:"derivedStartDate,omitempty"`
-	DerivedDueDate       interface{}           `json:"derivedDueDate,omitempty"`
-	SpentTime            string                `json:"spentTime,omitempty"`
+	DerivedStartDate     *Date                 `json:"derivedStartDate,omitempty"`
+	DerivedDueDate       *Date                 `json:"derivedDueDate,omitempty"`
+	SpentTime            *Duration             `json:"spentTime,omitempty"`
 	LaborCosts           string                `json:"laborCosts,omitempty"`
 	MaterialCosts        string                `json:"materialCosts,omitempty"`
 	OverallCosts         string                `json:"overallCosts,omitempty"`
@@ -80,10 +36,10 @@ type WorkPackage struct {
 	LockVersion          int                   `json:"lockVersion,omitempty" structs:"lockVersion,omitempty"`
 	Position             int                   `json:"position,omitempty" structs:"position,omitempty"`
 	Custom               tcontainer.MarshalMap `json:"custom,omitempty" structs:"custom,omitempty"`
-	EstimatedTime        string                `json:"estimatedTime,omitempty"`
-	DerivedEstimatedTime interface{}           `json:"derivedEstimatedTime,omitempty"`
+	EstimatedTime        *Duration             `json:"estimatedTime,omitempty"`
+	DerivedEstimatedTime *Duration             `json:"derivedEstimatedTime,omitempty"`
 	PercentageDone       int                   `json:"percentageDone,omitempty"`
-	RemainingTime        string                `json:"remainingTime,omitempty"`
+	RemainingTime        *Duration             `json:"remainingTime,omitempty"`
 
 	Links *WPLinks `json:"_links,omitempty" _links:"id,omitempty"`
 }
@@ -91,6 +47,23 @@ type WorkPackage struct {
 // WPDescription type contains description and format
 type WPDescription OPGenericDescription
 
+// WorkPackagePatch holds the changes of a work-package update. Nil fields are left unchanged, so properties can be
+// set to false or 0, e.g. &WorkPackagePatch{LockVersion: 3, ScheduleManually: Ptr(false)}.
+// A pointer to a zero Date clears a date.
+type WorkPackagePatch struct {
+	// LockVersion must be the lockVersion of the work-package the changes are based on, it is always sent
+	LockVersion      int        `json:"lockVersion" structs:"lockVersion"`
+	Subject          *string    `json:"subject,omitempty" structs:"subject,omitempty"`
+	Description      *TextPatch `json:"description,omitempty" structs:"description,omitempty"`
+	StartDate        *Date      `json:"startDate,omitempty" structs:"startDate,omitempty"`
+	DueDate          *Date      `json:"dueDate,omitempty" structs:"dueDate,omitempty"`
+	ScheduleManually *bool      `json:"scheduleManually,omitempty" structs:"scheduleManually,omitempty"`
+	EstimatedTime    *Duration  `json:"estimatedTime,omitempty" structs:"estimatedTime,omitempty"`
+	RemainingTime    *Duration  `json:"remainingTime,omitempty" structs:"remainingTime,omitempty"`
+	PercentageDone   *int       `json:"percentageDone,omitempty" structs:"percentageDone,omitempty"`
+	Links            *WPLinks   `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
 // WPLinks are WorkPackage Links
 type WPLinks struct {
 	Self                        *OPGenericLink  `json:"self,omitempty"`
@@ -133,31 +106,25 @@ type WPLinks struct {
 	Parent                      *OPGenericLink  `json:"parent,omitempty"`
 	Ancestors                   []OPGenericLink `json:"ancestors,omitempty"`
 	CustomActions               []OPGenericLink `json:"customActions,omitempty"`
-	RemoveWatcher               struct {
-		Href      string `json:"href,omitempty"`
-		Method    string `json:"method,omitempty"`
-		Templated bool   `json:"templated,omitempty"`
-	} `json:"removeWatcher,omitempty"`
-	AddWatcher struct {
-		Href    string `json:"href,omitempty"`
-		Method  string `json:"method,omitempty"`
-		Payload struct {
-			User struct {
-				Href string `json:"href,omitempty"`
-			} `json:"user,omitempty"`
-		} `json:"payload,omitempty"`
-		Templated bool `json:"templated,omitempty"`
-	} `json:"addWatcher,omitempty"`
-	CustomField1 []interface{} `json:"customField1,omitempty"`
-	Watch        struct {
-		Href    string `json:"href,omitempty"`
-		Method  string `json:"method,omitempty"`
-		Payload struct {
-			User struct {
-				Href string `json:"href,omitempty"`
-			} `json:"user,omitempty"`
-		} `json:"payload,omitempty"`
-	} `json:"watch,omitempty"`
+	RemoveWatcher               *WPActionLink   `json:"removeWatcher,omitempty"`
+	AddWatcher                  *WPActionLink   `json:"addWatcher,omitempty"`
+	CustomField1                []interface{}   `json:"customField1,omitempty"`
+	Watch                       *WPActionLink   `json:"watch,omitempty"`
+	Unwatch                     *WPActionLink   `json:"unwatch,omitempty"`
+}
+
+// WPActionLink is a work-package link to an action, like adding a watcher.
+// Templated links contain placeholders in their href, e.g. /api/v3/work_packages/1/watchers/{user_id}
+type WPActionLink struct {
+	Href      string           `json:"href,omitempty" structs:"href,omitempty"`
+	Method    string           `json:"method,omitempty" structs:"method,omitempty"`
+	Payload   *WPActionPayload `json:"payload,omitempty" structs:"payload,omitempty"`
+	Templated bool             `json:"templated,omitempty" structs:"templated,omitempty"`
+}
+
+// WPActionPayload is the body expected by a WPActionLink
+type WPActionPayload struct {
+	User *OPGenericLink `json:"user,omitempty" structs:"user,omitempty"`
 }
 
 // WPForm represents WorkPackage form
@@ -185,29 +152,48 @@ type WPPayload struct {
 type WPFormLinks struct {
 }
 
-// Constants to represent OpenProject standard GET parameters
-const paramFilters = "filters"
+// SearchResultWP is only a small wrapper around the Search
+type SearchResultWP struct {
+	Embedded SearchEmbeddedWP `json:"_embedded" structs:"_embedded"`
+	PaginationParam
+	// Groups are returned when grouping with ListOptions.GroupBy
+	Groups []WPGroup `json:"groups,omitempty" structs:"groups,omitempty"`
+	// TotalSums are returned when requesting ListOptions.ShowSums
+	TotalSums WPSums `json:"totalSums,omitempty" structs:"totalSums,omitempty"`
+}
 
-// FilterOptions allows you to specify search parameters for the get-workpackage action
-// When used they will be converted to GET parameters within the URL
-// Up to now OpenProject only allows "AND" combinations. "OR" combinations feature is under development,
-// tracked by this ticket https://community.openproject.org/projects/openproject/work_packages/26837/activity
-// More information about filters https://docs.openproject.org/api/filters/
-type FilterOptions struct {
-	Fields []OptionsFields
+// WPGroup is a group of a work-package list grouped by a property
+type WPGroup struct {
+	// Value is the value of the grouping property shared by the work-packages of the group
+	Value string `json:"value" structs:"value"`
+	// Count is the number of work-packages of the group
+	Count int `json:"count" structs:"count"`
+	// Sums of the group, returned when requesting ListOptions.ShowSums
+	Sums  WPSums       `json:"sums,omitempty" structs:"sums,omitempty"`
+	Links WPGroupLinks `json:"_links,omitempty" structs:"_links,omitempty"`
 }
 
-// OptionsFields array wraps field, Operator, Value within FilterOptions
-type OptionsFields struct {
-	Field    string
-	Operator SearchOperator
-	Value    string
+// WPGroupLinks represents WPGroup links
+type WPGroupLinks struct {
+	// ValueLink links the resources the group value stands for, e.g. the status
+	ValueLink []OPGenericLink `json:"valueLink,omitempty" structs:"valueLink,omitempty"`
 }
 
-// SearchResultWP is only a small wrapper around the Search
-type SearchResultWP struct {
-	Embedded SearchEmbeddedWP `json:"_embedded" structs:"_embedded"`
-	PaginationParam
+// WPSums holds the sums of summable properties keyed by property name, e.g. estimatedTime, storyPoints or customField3
+type WPSums map[string]interface{}
+
+// Duration returns a summed duration property like estimatedTime, spentTime or remainingTime.
+// ok is false when the property is not summed or is not a duration.
+func (s WPSums) Duration(property string) (d Duration, ok bool) {
+	value, isString := s[property].(string)
+	if !isString {
+		return 0, false
+	}
+	parsed, err := ParseISODuration(value)
+	if err != nil {
+		return 0, false
+	}
+	return Duration(parsed), true
 }
 
 func (s *SearchResultWP) TotalPage() int {
@@ -218,6 +204,11 @@ func (s *SearchResultWP) ConcatEmbed(wp interface{}) {
 	s.Embedded.Elements = append(s.Embedded.Elements, wp.(*SearchResultWP).Embedded.Elements...)
 }
 
+// Elements returns the work-packages of the page
+func (s *SearchResultWP) Elements() []WorkPackage {
+	return s.Embedded.Elements
+}
+
 // SearchEmbeddedWP represent elements within WorkPackage list
 type SearchEmbeddedWP struct {
 	Elements []WorkPackage `json:"elements" structs:"elements"`
@@ -239,47 +230,6 @@ func (s *WorkPackageService) Get(workpackageID string) (*WorkPackage, *Response,
 	return s.GetWithContext(context.Background(), workpackageID)
 }
 
-//	prepareFilters convert FilterOptions to single URL-Encoded string to be inserted into GET request
-//
-// as parameter.
-func (fops *FilterOptions) prepareFilters(oldValues url.Values) url.Values {
-	values := oldValues
-	if oldValues == nil {
-		values = make(url.Values)
-	}
-
-	filterTemplate := "["
-	for idx, field := range fops.Fields {
-		value := ""
-		if strings.Contains(field.Value, ",") {
-			parts := strings.Split(field.Value, ",")
-			for i, part := range parts {
-				value += fmt.Sprintf(`"%s"`, part)
-				if i < len(parts)-1 {
-					value += ","
-				}
-			}
-		} else {
-			value = `"` + field.Value + `"`
-		}
-
-		s := fmt.Sprintf(
-			"{\"%[1]v\":{\"operator\":\"%[2]v\",\"values\":[%[3]v]}}",
-			field.Field, field.Operator, value)
-
-		filterTemplate += s
-
-		if idx != len(fops.Fields)-1 {
-			filterTemplate += ","
-		}
-	}
-	filterTemplate += "]"
-
-	values.Add(paramFilters, filterTemplate)
-
-	return values
-}
-
 // CreateWithContext creates a work-package or a sub-task from a JSON representation.
 func (s *WorkPackageService) CreateWithContext(ctx context.Context, wpObject *WorkPackage, projectName string) (*WorkPackage, *Response, error) {
 	apiEndpoint := fmt.Sprintf("api/v3/projects/%s/work_packages", projectName)
@@ -295,6 +245,58 @@ func (s *WorkPackageService) Create(wpObject *WorkPackage, projectName string) (
 	return s.CreateWithContext(context.Background(), wpObject, projectName)
 }
 
+// UpdateWithContext updates a work-package sending only the fields set in patch (HTTP PATCH verb).
+// If the work-package has been modified since patch.LockVersion OpenProject answers 409 Conflict and a *ConflictError
+// is returned.
+func (s *WorkPackageService) UpdateWithContext(ctx context.Context, workpackageID string, patch *WorkPackagePatch) (*WorkPackage, *Response, error) {
+	if patch == nil {
+		return nil, nil, errors.New("work-package patch is nil")
+	}
+
+	apiEndpoint := fmt.Sprintf("api/v3/work_packages/%s", workpackageID)
+	wpResponse, resp, err := UpdateWithContext(ctx, patch, s, apiEndpoint)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			err = &ConflictError{LockVersion: patch.LockVersion, HTTPError: err}
+		}
+		return nil, resp, err
+	}
+	return wpResponse.(*WorkPackage), resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *WorkPackageService) Update(workpackageID string, patch *WorkPackagePatch) (*WorkPackage, *Response, error) {
+	return s.UpdateWithContext(context.Background(), workpackageID, patch)
+}
+
+// UpdateWithConflictRetryWithContext works like UpdateWithContext but, when the update is rejected because of
+// a conflict, it re-fetches the work-package and applies the same changes again on top of the current lockVersion.
+// It gives up after maxRetries retries returning the last *ConflictError. patch is not modified.
+func (s *WorkPackageService) UpdateWithConflictRetryWithContext(ctx context.Context, workpackageID string, patch *WorkPackagePatch, maxRetries int) (*WorkPackage, *Response, error) {
+	if patch == nil {
+		return nil, nil, errors.New("work-package patch is nil")
+	}
+	changes := *patch
+	for attempt := 0; ; attempt++ {
+		wp, resp, err := s.UpdateWithContext(ctx, workpackageID, &changes)
+		var conflict *ConflictError
+		if err == nil || !errors.As(err, &conflict) || attempt >= maxRetries {
+			return wp, resp, err
+		}
+
+		current, resp, err := s.GetWithContext(ctx, workpackageID)
+		if err != nil {
+			return nil, resp, err
+		}
+		changes.LockVersion = current.LockVersion
+	}
+}
+
+// UpdateWithConflictRetry wraps UpdateWithConflictRetryWithContext using the background context.
+func (s *WorkPackageService) UpdateWithConflictRetry(workpackageID string, patch *WorkPackagePatch, maxRetries int) (*WorkPackage, *Response, error) {
+	return s.UpdateWithConflictRetryWithContext(context.Background(), workpackageID, patch, maxRetries)
+}
+
 // GetListWithContext will retrieve a list of work-packages using filters
 func (s *WorkPackageService) GetListWithContext(ctx context.Context, options *FilterOptions, offset int, pageSize int) (*SearchResultWP, *Response, error) {
 	u := url.URL{
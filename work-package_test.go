@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/trivago/tgo/tcontainer"
+	"io"
 	"net/http"
 	"os"
 	"reflect"
@@ -123,7 +124,7 @@ func TestWorkPackageService_Delete(t *testing.T) {
 }
 
 func TestWorkPackageFields_MarshalJSON_OmitsEmptyFields(t *testing.T) {
-	thisDate, _ := time.Parse("2006-01-02T15:04:05-0700", "2020-12-31")
+	thisDate, _ := time.Parse("2006-01-02", "2020-12-31")
 	thisDateTime := Time(thisDate)
 
 	i := &WorkPackage{
@@ -166,7 +167,7 @@ func TestWorkPackageFields_MarshalJSON_OmitsEmptyFields(t *testing.T) {
 }
 
 func TestWorkPackageCustomFields_MarshalJSON_Success(t *testing.T) {
-	thisDate, _ := time.Parse("2006-01-02T15:04:05-0700", "2020-12-31")
+	thisDate, _ := time.Parse("2006-01-02", "2020-12-31")
 	thisDateTime := Time(thisDate)
 
 	i := &WorkPackage{
@@ -197,3 +198,133 @@ func TestWorkPackageCustomFields_MarshalJSON_Success(t *testing.T) {
 		t.Errorf("Received object different from expected. Expected %+v, received %+v", i, received)
 	}
 }
+
+func TestWorkPackageService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := os.ReadFile("./mocks/get/get-workpackage.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/work_packages/36350", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testRequestURL(t, r, "/api/v3/work_packages/36350")
+
+		body := make(map[string]interface{})
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Error decoding body: %s", err)
+		}
+		if v, ok := body["lockVersion"]; !ok || v != float64(0) {
+			t.Errorf("Expected lockVersion 0 to be sent, got %v", v)
+		}
+		if body["subject"] != "New subject" {
+			t.Errorf("Expected subject to be sent, got %v", body["subject"])
+		}
+
+		fmt.Fprint(w, string(raw))
+	})
+
+	wp, _, err := testClient.WorkPackage.Update("36350", &WorkPackagePatch{Subject: Ptr("New subject")})
+	if wp == nil {
+		t.Error("Expected work-package. Work-package is nil")
+	}
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestWorkPackageService_Update_ZeroValues(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/work_packages/36350", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		body, _ := io.ReadAll(r.Body)
+		want := `{"lockVersion":2,"description":{"raw":""},"dueDate":null,"scheduleManually":false,"percentageDone":0}`
+		if string(body) != want+"\n" {
+			t.Errorf("Expected body %s. Got %s", want, body)
+		}
+		fmt.Fprint(w, `{"_type":"WorkPackage","id":36350,"lockVersion":3}`)
+	})
+
+	patch := &WorkPackagePatch{
+		LockVersion:      2,
+		Description:      &TextPatch{},
+		DueDate:          &Date{},
+		ScheduleManually: Ptr(false),
+		PercentageDone:   Ptr(0),
+	}
+	if _, _, err := testClient.WorkPackage.Update("36350", patch); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestWorkPackageService_Update_Conflict(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/work_packages/36350", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+
+		w.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:UpdateConflict","message":"Your changes could not be saved, because the work package was changed by someone else in the meantime."}`)
+	})
+
+	wp, resp, err := testClient.WorkPackage.Update("36350", &WorkPackagePatch{Subject: Ptr("New subject"), LockVersion: 3})
+	if wp != nil {
+		t.Errorf("Expected nil work-package. Got %+v", wp)
+	}
+	if resp == nil || resp.StatusCode != http.StatusConflict {
+		t.Errorf("Expected response with status 409. Got %+v", resp)
+	}
+	conflict, ok := err.(*ConflictError)
+	if !ok {
+		t.Fatalf("Expected *ConflictError. Got %T: %v", err, err)
+	}
+	if conflict.LockVersion != 3 {
+		t.Errorf("Expected lockVersion 3. Got %d", conflict.LockVersion)
+	}
+}
+
+func TestWorkPackageService_UpdateWithConflictRetry(t *testing.T) {
+	setup()
+	defer teardown()
+	patches := 0
+	testMux.HandleFunc("/api/v3/work_packages/36350", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"_type":"WorkPackage","id":36350,"subject":"Changed by someone else","lockVersion":5}`)
+		case "PATCH":
+			patches++
+			body := make(map[string]interface{})
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Error decoding body: %s", err)
+			}
+			if body["subject"] != "New subject" {
+				t.Errorf("Expected subject to be re-applied, got %v", body["subject"])
+			}
+			if body["lockVersion"] != float64(5) {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprint(w, `{}`)
+				return
+			}
+			fmt.Fprint(w, `{"_type":"WorkPackage","id":36350,"subject":"New subject","lockVersion":6}`)
+		default:
+			t.Errorf("Unexpected request method %s", r.Method)
+		}
+	})
+
+	patch := &WorkPackagePatch{Subject: Ptr("New subject"), LockVersion: 4}
+	wp, _, err := testClient.WorkPackage.UpdateWithConflictRetry("36350", patch, 1)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if wp.LockVersion != 6 {
+		t.Errorf("Expected lockVersion 6. Got %d", wp.LockVersion)
+	}
+	if patches != 2 {
+		t.Errorf("Expected 2 PATCH requests. Got %d", patches)
+	}
+	if patch.LockVersion != 4 {
+		t.Errorf("Expected caller patch to be left untouched. Got lockVersion %d", patch.LockVersion)
+	}
+}